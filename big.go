@@ -0,0 +1,130 @@
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// NewDecimalFromBigInt - Creates a new decimal from a big integer like NewDecimal, for amounts that
+// don't fit in an int64. Amount represents the decimal as decimal * 10^precision, so amount 12345 with
+// precision 2 is 123.45. The amount is copied
+func NewDecimalFromBigInt(amount *big.Int, precision uint) *Decimal {
+	return newDecimalFromBigInt(amount, precision)
+}
+
+// NewDecimalFromRat - Creates a new decimal from a rational number, rounded to the precision using
+// the rounding mode, and returns whether the decimal is exactly equal to it. For example, 1/3 with
+// precision 4 is 0.3333 and not exact, while 1/4 with precision 4 is 0.2500 and exact
+func NewDecimalFromRat(r *big.Rat, precision uint, mode RoundingMode) (d *Decimal, exact bool) {
+	scaled := new(big.Int).Mul(r.Num(), bigPow10(precision))
+	d = newDecimalFromBigInt(bigDivRound(scaled, r.Denom(), mode), precision)
+	return d, scaled.Rem(scaled, r.Denom()).Sign() == 0
+}
+
+// NewDecimalFromBigFloat - Creates a new decimal from a big.Float, rounded to the precision using the
+// rounding mode like NewDecimalFromRat, and returns whether the decimal is exactly equal to it. The
+// value of the float is taken exactly, so 0.1 as a float with 53 bits of mantissa isn't exact at any
+// precision below 55. It returns an error matching ErrOutOfRange for infinities
+func NewDecimalFromBigFloat(f *big.Float, precision uint, mode RoundingMode) (d *Decimal, exact bool, err error) {
+	if f.IsInf() {
+		return nil, false, fmt.Errorf("convert big.Float %v: %w", f, ErrOutOfRange)
+	}
+	r, _ := f.Rat(nil)
+	d, exact = NewDecimalFromRat(r, precision, mode)
+	return d, exact, nil
+}
+
+// ToBigInt - Returns the integer representation of decimal multiplied by 10^precision, like ToInt,
+// as a new big.Int. The result is exact however large the decimal is
+func (d Decimal) ToBigInt() *big.Int {
+	return d.bigInt()
+}
+
+// ToRat - Returns the decimal as a new rational number. The result is exact
+func (d Decimal) ToRat() *big.Rat {
+	return new(big.Rat).SetFrac(d.bigInt(), bigPow10(d.precision))
+}
+
+// ToBigFloat - Returns the decimal as a new big.Float with prec bits of mantissa, rounded to nearest
+// even, and whether the result is exact, below or above the decimal. Decimals with fraction digits
+// other than multiples of powers of two, like 0.1, are never exact. If prec is 0, the precision is
+// chosen as by big.Float.SetRat
+func (d Decimal) ToBigFloat(prec uint) (*big.Float, big.Accuracy) {
+	f := new(big.Float).SetPrec(prec).SetRat(d.ToRat())
+	return f, f.Acc()
+}
+
+// magnitude - Returns the magnitude of the coefficient of the decimal as a new big.Int
+func (d Decimal) magnitude() *big.Int {
+	if d.bigCoefficient != nil {
+		return new(big.Int).Set(d.bigCoefficient)
+	}
+	return new(big.Int).SetUint64(d.coefficient)
+}
+
+// bigInt - Returns the integer representation of decimal multiplied by 10^precision, like ToInt,
+// as a new big.Int
+func (d Decimal) bigInt() *big.Int {
+	value := d.magnitude()
+	if d.negative {
+		value.Neg(value)
+	}
+	return value
+}
+
+// bigIntAt - Returns the integer representation of decimal at a precision that is at least the
+// precision of the decimal as a new big.Int
+func (d Decimal) bigIntAt(precision uint) *big.Int {
+	value := d.bigInt()
+	return value.Mul(value, bigPow10(precision-d.precision))
+}
+
+// setMagnitude - Sets the magnitude of the coefficient of the decimal. The coefficient is kept in
+// an uint64 when it fits and m must not be modified afterwards otherwise
+func (d *Decimal) setMagnitude(m *big.Int) {
+	if m.IsUint64() {
+		d.coefficient = m.Uint64()
+		d.bigCoefficient = nil
+	} else {
+		d.coefficient = 0
+		d.bigCoefficient = m
+	}
+}
+
+// newDecimalFromBigInt - Creates a new decimal from a big integer representing the decimal as
+// decimal * 10^precision, like NewDecimal
+func newDecimalFromBigInt(amount *big.Int, precision uint) *Decimal {
+	d := NewDecimal(0, precision)
+	d.negative = amount.Sign() < 0
+	d.setMagnitude(new(big.Int).Abs(amount))
+	return d
+}
+
+// bigPow10 - Returns 10 to the power of n as a new big.Int
+func bigPow10(n uint) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), new(big.Int).SetUint64(uint64(n)), nil)
+}
+
+// bigDivRound - Divides two big integers rounding the quotient using the rounding mode. It
+// returns a new big.Int and panics if the divisor is zero
+func bigDivRound(dividend, divisor *big.Int, mode RoundingMode) *big.Int {
+	if divisor.Sign() == 0 {
+		panic(ErrDivisionByZero)
+	}
+	quotient, remainder := new(big.Int).QuoRem(dividend, divisor, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+	// Compare the remainder to half of the divisor as 2 * |remainder| against |divisor|
+	remainder.Abs(remainder)
+	half := remainder.Lsh(remainder, 1).CmpAbs(divisor)
+	negative := dividend.Sign() != divisor.Sign()
+	lastDigit := new(big.Int).Rem(quotient, big.NewInt(10))
+	if !mode.roundsAway(negative, lastDigit.Abs(lastDigit).Uint64(), half) {
+		return quotient
+	}
+	if negative {
+		return quotient.Sub(quotient, big.NewInt(1))
+	}
+	return quotient.Add(quotient, big.NewInt(1))
+}
@@ -0,0 +1,228 @@
+package decimal_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/petrossordinas/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArbitraryPrecisionArithmetic(t *testing.T) {
+	tests := []struct {
+		op      string
+		strVal1 string
+		strVal2 string
+		result  string
+	}{
+		{"add", "9223372036854775807", "1", "9223372036854775808"},
+		{"add", "-9223372036854775808", "-1", "-9223372036854775809"},
+		{"add", "18446744073709551615", "1", "18446744073709551616"},
+		{"add", "1.000000000000000000", "0.000000000000000001", "1.000000000000000001"},
+		{"add", "1000000000000000000000", "0.000000000000000001", "1000000000000000000000.000000000000000001"},
+		{"add", "100000000000000000000", "-100000000000000000000", "0"},
+		{"subtract", "-9223372036854775808", "1", "-9223372036854775809"},
+		{"subtract", "100000000000000000000.5", "100000000000000000000", "0.5"},
+		{"subtract", "0.1", "0.0000000000000000000000001", "0.0999999999999999999999999"},
+		{"multiply", "9223372036854775807", "10", "92233720368547758070"},
+		{"multiply", "1.5", "1000000000000000000000", "1500000000000000000000.0"},
+		{"multiply", "0.000000000000000000001", "0.000000000000000000005", "0.000000000000000000000"},
+		{"multiply", "123456789012345678901234567890", "-2", "-246913578024691357802469135780"},
+		{"divide", "1", "3.0000000000000000000", "0.3333333333333333333"},
+		{"divide", "2", "3.0000000000000000000", "0.6666666666666666667"},
+		{"divide", "100000000000000000000000", "4", "25000000000000000000000"},
+		{"divide", "-1000000000000000000000.00", "3", "-333333333333333333333.33"},
+		{"divide", "1000000000000000000000", "1000000000000000000000", "1"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1, err := decimal.NewDecimalFromString(tc.strVal1)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		d2, err := decimal.NewDecimalFromString(tc.strVal2)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		var result decimal.Decimal
+		switch tc.op {
+		case "add":
+			result = d1.Add(*d2)
+		case "subtract":
+			result = d1.Subtract(*d2)
+		case "multiply":
+			result = d1.Multiply(*d2)
+		case "divide":
+			result = d1.Divide(*d2)
+		}
+		assert.Equal(tc.result, result.ToString(), "Test No: %d - Should be equal", testNo+1)
+		expected, err := decimal.NewDecimalFromString(tc.result)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(*expected, result, "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestArbitraryPrecisionPromotion(t *testing.T) {
+	assert := assert.New(t)
+	max := decimal.NewDecimal(math.MaxInt64, 0)
+	promoted := max.AddInt(1)
+	assert.Equal("9223372036854775808", promoted.ToString())
+	assert.True(promoted.GreaterThan(*max))
+	assert.True(promoted.IsPositive())
+	// Going back into the int64 range gives the same decimal as int64 arithmetic
+	demoted := promoted.SubtractInt(1)
+	assert.Equal(*max, demoted)
+	assert.Equal(int64(math.MaxInt64), demoted.ToInt())
+	_, err := max.AddChecked(*decimal.NewDecimal(1, 0))
+	assert.ErrorIs(err, decimal.ErrOverflow)
+}
+
+func TestArbitraryPrecisionMethods(t *testing.T) {
+	assert := assert.New(t)
+	wei, err := decimal.NewDecimalFromString("1234567.890123456789012345")
+	assert.Nil(err)
+	assert.Equal(uint(18), wei.GetPrecision())
+	assert.Equal(int64(1234567), wei.GetWhole())
+	assert.Equal(int64(890123456789012345), wei.GetFraction())
+	assert.Equal("1.234.567,890123456789012345", wei.ToStringFormatted())
+	assert.Equal(1234567.890123456789012345, wei.ToFloat())
+	rounded := wei.Round(2, decimal.RoundHalfUp)
+	assert.Equal("1234567.89", rounded.ToString())
+	rounded = wei.Round(30, decimal.RoundHalfUp)
+	assert.Equal("1234567.890123456789012345000000000000", rounded.ToString())
+	assert.True(rounded.Equal(*wei))
+	large, err := decimal.NewDecimalFromString("-123456789012345678901234567890.5")
+	assert.Nil(err)
+	assert.True(large.IsNegative())
+	assert.False(large.IsZero())
+	assert.Equal(-1, large.Cmp(*wei))
+	assert.Equal(1, wei.Cmp(*large))
+	assert.Equal("-123456789012345678901234567891", large.Round(0, decimal.RoundHalfUp).ToString())
+	assert.Equal("-123456789012345678901234567890", large.Round(0, decimal.RoundHalfEven).ToString())
+	assert.Equal("-123.456.789.012.345.678.901.234.567.890,5", large.ToStringFormatted())
+	parts := large.Split(2)
+	assert.Equal("-61728394506172839450617283945.3", parts[0].ToString())
+	assert.Equal("-61728394506172839450617283945.2", parts[1].ToString())
+	assert.Equal(*large, parts[0].Add(parts[1]))
+}
+
+func TestNewDecimalFromRat(t *testing.T) {
+	tests := []struct {
+		rat       string
+		precision uint
+		mode      decimal.RoundingMode
+		result    string
+		exact     bool
+	}{
+		{"1/3", 4, decimal.RoundHalfUp, "0.3333", false},
+		{"2/3", 4, decimal.RoundHalfUp, "0.6667", false},
+		{"2/3", 4, decimal.RoundDown, "0.6666", false},
+		{"-2/3", 4, decimal.RoundFloor, "-0.6667", false},
+		{"1/4", 4, decimal.RoundHalfUp, "0.2500", true},
+		{"1/8", 2, decimal.RoundHalfEven, "0.12", false},
+		{"5", 0, decimal.RoundHalfUp, "5", true},
+		{"1/7", 30, decimal.RoundHalfUp, "0.142857142857142857142857142857", false},
+		{"123456789012345678901234567890/1", 2, decimal.RoundHalfUp, "123456789012345678901234567890.00", true},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		r, _ := new(big.Rat).SetString(tc.rat)
+		d, exact := decimal.NewDecimalFromRat(r, tc.precision, tc.mode)
+		assert.Equal(tc.result, d.ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.exact, exact, "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestBigConversions(t *testing.T) {
+	tests := []struct {
+		strVal string
+		bigInt string
+		rat    string
+	}{
+		{"123.45", "12345", "2469/20"},
+		{"-0.001", "-1", "-1/1000"},
+		{"0.00", "0", "0/1"},
+		{"123456789012345678901234567890.5", "1234567890123456789012345678905", "246913578024691357802469135781/2"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, _ := decimal.NewDecimalFromString(tc.strVal)
+		assert.Equal(tc.bigInt, d.ToBigInt().String(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.rat, d.ToRat().String(), "Test No: %d - Should be equal", testNo+1)
+		// Converting back gives the same decimal
+		assert.Equal(*d, *decimal.NewDecimalFromBigInt(d.ToBigInt(), d.GetPrecision()), "Test No: %d - Should be equal", testNo+1)
+		fromRat, exact := decimal.NewDecimalFromRat(d.ToRat(), d.GetPrecision(), decimal.RoundHalfUp)
+		assert.True(exact, "Test No: %d - Was expecting exact", testNo+1)
+		assert.Equal(*d, *fromRat, "Test No: %d - Should be equal", testNo+1)
+	}
+	// The big integer is copied, so changing it doesn't change the decimal
+	amount := big.NewInt(12345)
+	d := decimal.NewDecimalFromBigInt(amount, 2)
+	amount.SetInt64(1)
+	assert.Equal("123.45", d.ToString())
+	d.ToBigInt().SetInt64(1)
+	assert.Equal("123.45", d.ToString())
+}
+
+func TestToBigFloat(t *testing.T) {
+	tests := []struct {
+		strVal   string
+		prec     uint
+		result   string
+		accuracy big.Accuracy
+	}{
+		{"0.5", 53, "0.5", big.Exact},
+		{"-2.25", 53, "-2.25", big.Exact},
+		{"0.1", 53, "0.1", big.Above},
+		{"0.1", 200, "0.1", big.Above},
+		{"123456789012345678901234567890", 200, "123456789012345678901234567890", big.Exact},
+		{"123456789012345678901234567890", 53, "123456789012345680000000000000", big.Below},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, _ := decimal.NewDecimalFromString(tc.strVal)
+		f, accuracy := d.ToBigFloat(tc.prec)
+		assert.Equal(tc.result, f.Text('f', -1), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.accuracy, accuracy, "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.prec, f.Prec(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestNewDecimalFromBigFloat(t *testing.T) {
+	tests := []struct {
+		float     string
+		prec      uint
+		precision uint
+		mode      decimal.RoundingMode
+		result    string
+		exact     bool
+	}{
+		{"0.5", 53, 2, decimal.RoundHalfUp, "0.50", true},
+		{"-2.25", 53, 1, decimal.RoundHalfEven, "-2.2", false},
+		{"-2.25", 53, 1, decimal.RoundHalfUp, "-2.3", false},
+		{"0.1", 53, 2, decimal.RoundHalfUp, "0.10", false},
+		{"0.1", 53, 55, decimal.RoundHalfUp, "0.1000000000000000055511151231257827021181583404541015625", true},
+		{"1e30", 200, 0, decimal.RoundHalfUp, "1000000000000000000000000000000", true},
+		{"0", 53, 2, decimal.RoundHalfUp, "0.00", true},
+		{"-0", 53, 0, decimal.RoundHalfUp, "0", true},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		f, _, _ := big.ParseFloat(tc.float, 10, tc.prec, big.ToNearestEven)
+		d, exact, err := decimal.NewDecimalFromBigFloat(f, tc.precision, tc.mode)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(tc.result, d.ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.exact, exact, "Test No: %d - Should be equal", testNo+1)
+	}
+	// Infinities can't be converted
+	for _, inf := range []*big.Float{new(big.Float).SetInf(false), new(big.Float).SetInf(true)} {
+		d, exact, err := decimal.NewDecimalFromBigFloat(inf, 2, decimal.RoundHalfUp)
+		assert.ErrorIs(err, decimal.ErrOutOfRange)
+		assert.Nil(d)
+		assert.False(exact)
+	}
+	// Converting back from ToBigFloat gives the same decimal when it is exact
+	d, _ := decimal.NewDecimalFromString("-123.375")
+	f, _ := d.ToBigFloat(64)
+	back, exact, err := decimal.NewDecimalFromBigFloat(f, 3, decimal.RoundHalfUp)
+	assert.Nil(err)
+	assert.True(exact)
+	assert.Equal(*d, *back)
+}
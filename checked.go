@@ -0,0 +1,122 @@
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrDivisionByZero is returned when dividing by a zero decimal
+	ErrDivisionByZero = errors.New("division by zero")
+	// ErrOverflow is returned when the result of a checked operation doesn't fit in a 64-bit scaled
+	// integer, i.e. when ToInt of the result would overflow, or when a checked conversion doesn't fit
+	// in the integer type
+	ErrOverflow = errors.New("overflow")
+	// ErrInexact is returned when an operation or a checked conversion would discard non-zero
+	// digits. The errors of a Context trapping Inexact match it as well
+	ErrInexact = errors.New("inexact")
+)
+
+// AddChecked - Adds a decimal to another decimal like Add. It returns ErrOverflow if the sum
+// can't be represented by a 64-bit scaled integer, for code that stores decimals as one
+func (d Decimal) AddChecked(decimalToAdd Decimal) (Decimal, error) {
+	sum := d.Add(decimalToAdd)
+	if !sum.fitsInt64() {
+		return Decimal{}, fmt.Errorf("add decimal: %w", ErrOverflow)
+	}
+	return sum, nil
+}
+
+// SubtractChecked - Subtracts a decimal from another decimal like Subtract. It returns ErrOverflow
+// if the difference can't be represented by a 64-bit scaled integer
+func (d Decimal) SubtractChecked(decimalToSubtract Decimal) (Decimal, error) {
+	difference := d.Subtract(decimalToSubtract)
+	if !difference.fitsInt64() {
+		return Decimal{}, fmt.Errorf("subtract decimal: %w", ErrOverflow)
+	}
+	return difference, nil
+}
+
+// MultiplyChecked - Multiplies a decimal with another decimal like Multiply. It returns ErrOverflow
+// if the product can't be represented by a 64-bit scaled integer
+func (d Decimal) MultiplyChecked(factor Decimal) (Decimal, error) {
+	product := d.Multiply(factor)
+	if !product.fitsInt64() {
+		return Decimal{}, fmt.Errorf("multiply decimal: %w", ErrOverflow)
+	}
+	return product, nil
+}
+
+// DivideChecked - Divides a decimal with another decimal like Divide. It returns ErrDivisionByZero
+// if the divisor is zero and ErrOverflow if the quotient can't be represented by a 64-bit scaled
+// integer
+func (d Decimal) DivideChecked(divisor Decimal) (Decimal, error) {
+	if divisor.IsZero() {
+		return Decimal{}, fmt.Errorf("divide decimal: %w", ErrDivisionByZero)
+	}
+	quotient := d.Divide(divisor)
+	if !quotient.fitsInt64() {
+		return Decimal{}, fmt.Errorf("divide decimal: %w", ErrOverflow)
+	}
+	return quotient, nil
+}
+
+// ToMinorUnits - Returns the decimal as an integer amount of minor units of a currency with the given
+// exponent, i.e. decimal * 10^exponent, as payment gateways expect it. For example, 10.50 is 1050 with
+// exponent 2, as for cents, and 10500 with exponent 3. It returns an error matching ErrInexact if the
+// decimal has non-zero digits beyond the exponent, like 10.505 with exponent 2, and ErrOverflow if the
+// amount doesn't fit in an int64
+func (d Decimal) ToMinorUnits(exponent uint) (int64, error) {
+	return d.int64At(exponent, "minor units")
+}
+
+// Int64 - Returns the decimal as an int64. It returns an error matching ErrInexact if the decimal has a
+// non-zero fraction and ErrOverflow if it doesn't fit in an int64. Round the decimal to precision 0
+// first to get a rounded integer
+func (d Decimal) Int64() (int64, error) {
+	return d.int64At(0, "int64")
+}
+
+// Int32 - Returns the decimal as an int32 like Int64. It returns ErrOverflow if the decimal doesn't
+// fit in an int32
+func (d Decimal) Int32() (int32, error) {
+	value, err := d.int64At(0, "int32")
+	if err == nil && (value < math.MinInt32 || value > math.MaxInt32) {
+		return 0, fmt.Errorf("convert decimal %s to int32: %w", d, ErrOverflow)
+	}
+	return int32(value), err
+}
+
+// Uint64 - Returns the decimal as an uint64 like Int64. It returns ErrOverflow if the decimal is
+// negative or doesn't fit in an uint64
+func (d Decimal) Uint64() (uint64, error) {
+	whole, exact := d.Rescale(0, RoundDown)
+	if !exact {
+		return 0, fmt.Errorf("convert decimal %s to uint64: %w", d, ErrInexact)
+	}
+	if whole.IsNegative() || whole.bigCoefficient != nil {
+		return 0, fmt.Errorf("convert decimal %s to uint64: %w", d, ErrOverflow)
+	}
+	return whole.coefficient, nil
+}
+
+// int64At - Returns decimal * 10^precision as an int64, or an error if that has a non-zero fraction
+// or doesn't fit in an int64. typ names the conversion in errors
+func (d Decimal) int64At(precision uint, typ string) (int64, error) {
+	scaled, exact := d.Rescale(precision, RoundDown)
+	if !exact {
+		return 0, fmt.Errorf("convert decimal %s to %s: %w", d, typ, ErrInexact)
+	}
+	value, ok := scaled.toInt()
+	if !ok {
+		return 0, fmt.Errorf("convert decimal %s to %s: %w", d, typ, ErrOverflow)
+	}
+	return value, nil
+}
+
+// fitsInt64 - Returns true if the integer representation of decimal fits in an int64
+func (d Decimal) fitsInt64() bool {
+	_, ok := d.toInt()
+	return ok
+}
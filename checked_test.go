@@ -0,0 +1,204 @@
+package decimal_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/petrossordinas/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddChecked(t *testing.T) {
+	tests := []struct {
+		intVal1    int64
+		precision1 uint
+		intVal2    int64
+		precision2 uint
+		err        error
+	}{
+		{2456, 2, 544, 2, nil},
+		{math.MaxInt64 - 1, 0, 1, 0, nil},
+		{math.MaxInt64, 0, 1, 0, decimal.ErrOverflow},
+		{math.MinInt64, 0, -1, 0, decimal.ErrOverflow},
+		{math.MaxInt64, 0, 1, 1, decimal.ErrOverflow},
+		{1, 18, 1, 0, nil},
+		{1, 18, 10, 0, decimal.ErrOverflow},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1 := decimal.NewDecimal(tc.intVal1, tc.precision1)
+		d2 := decimal.NewDecimal(tc.intVal2, tc.precision2)
+		sum, err := d1.AddChecked(*d2)
+		assert.ErrorIs(err, tc.err, "Test No: %d - Should be equal", testNo+1)
+		if tc.err == nil {
+			assert.Equal(d1.Add(*d2), sum, "Test No: %d - Should be equal", testNo+1)
+		}
+	}
+}
+
+func TestSubtractChecked(t *testing.T) {
+	tests := []struct {
+		intVal1    int64
+		precision1 uint
+		intVal2    int64
+		precision2 uint
+		err        error
+	}{
+		{2456, 2, 544, 2, nil},
+		{math.MinInt64 + 1, 0, 1, 0, nil},
+		{math.MinInt64, 0, 1, 0, decimal.ErrOverflow},
+		{math.MaxInt64, 0, -1, 0, decimal.ErrOverflow},
+		{0, 0, math.MinInt64, 0, decimal.ErrOverflow},
+		{-1, 0, math.MinInt64, 0, nil},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1 := decimal.NewDecimal(tc.intVal1, tc.precision1)
+		d2 := decimal.NewDecimal(tc.intVal2, tc.precision2)
+		difference, err := d1.SubtractChecked(*d2)
+		assert.ErrorIs(err, tc.err, "Test No: %d - Should be equal", testNo+1)
+		if tc.err == nil {
+			assert.Equal(d1.Subtract(*d2), difference, "Test No: %d - Should be equal", testNo+1)
+		}
+	}
+}
+
+func TestMultiplyChecked(t *testing.T) {
+	tests := []struct {
+		intVal1    int64
+		precision1 uint
+		intVal2    int64
+		precision2 uint
+		result     string
+		err        error
+	}{
+		{2456, 2, 544, 2, "133.61", nil},
+		{1234567890123, 12, 1234567890123, 12, "1.524157875323", nil},
+		{4611686018427387904, 0, 2, 0, "", decimal.ErrOverflow},
+		{4611686018427387904, 0, -2, 0, "-9223372036854775808", nil},
+		{3037000500, 0, 3037000500, 0, "", decimal.ErrOverflow},
+		{123456789, 2, 100000000000, 0, "", decimal.ErrOverflow},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1 := decimal.NewDecimal(tc.intVal1, tc.precision1)
+		d2 := decimal.NewDecimal(tc.intVal2, tc.precision2)
+		product, err := d1.MultiplyChecked(*d2)
+		assert.ErrorIs(err, tc.err, "Test No: %d - Should be equal", testNo+1)
+		if tc.err == nil {
+			assert.Equal(tc.result, product.ToString(), "Test No: %d - Should be equal", testNo+1)
+		}
+	}
+}
+
+func TestDivideChecked(t *testing.T) {
+	tests := []struct {
+		intVal1    int64
+		precision1 uint
+		intVal2    int64
+		precision2 uint
+		result     int64
+		err        error
+	}{
+		{1000, 2, 300, 2, 333, nil},
+		{1000, 2, 0, 2, 0, decimal.ErrDivisionByZero},
+		{0, 0, 0, 0, 0, decimal.ErrDivisionByZero},
+		{123456789012345678, 2, 1, 0, 123456789012345678, nil},
+		{123456789012345678, 0, 1, 2, 0, decimal.ErrOverflow},
+		{math.MinInt64, 0, -1, 0, 0, decimal.ErrOverflow},
+		{1000000000000000000, 18, 3, 0, 333333333333333333, nil},
+		{1, 0, 3, 18, 0, decimal.ErrOverflow},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1 := decimal.NewDecimal(tc.intVal1, tc.precision1)
+		d2 := decimal.NewDecimal(tc.intVal2, tc.precision2)
+		quotient, err := d1.DivideChecked(*d2)
+		assert.ErrorIs(err, tc.err, "Test No: %d - Should be equal", testNo+1)
+		if tc.err == nil {
+			assert.Equal(tc.result, quotient.ToInt(), "Test No: %d - Should be equal", testNo+1)
+		}
+	}
+}
+
+func TestDivideByZeroPanics(t *testing.T) {
+	assert := assert.New(t)
+	d := decimal.NewDecimal(1000, 2)
+	assert.PanicsWithValue(decimal.ErrDivisionByZero, func() { d.Divide(*decimal.NewDecimal(0, 2)) })
+	assert.PanicsWithValue(decimal.ErrDivisionByZero, func() { d.DivideByInt(0) })
+}
+
+func TestToMinorUnits(t *testing.T) {
+	tests := []struct {
+		strVal   string
+		exponent uint
+		result   int64
+		err      error
+	}{
+		{"10.50", 2, 1050, nil},
+		{"10.50", 3, 10500, nil},
+		{"10.50", 0, 0, decimal.ErrInexact},
+		{"10.500", 2, 1050, nil},
+		{"-10.5", 2, -1050, nil},
+		{"10.505", 2, 0, decimal.ErrInexact},
+		{"1234", 0, 1234, nil},
+		{"92233720368547758.07", 2, math.MaxInt64, nil},
+		{"92233720368547758.08", 2, 0, decimal.ErrOverflow},
+		{"-92233720368547758.08", 2, math.MinInt64, nil},
+		{"123456789012345678901234567890", 0, 0, decimal.ErrOverflow},
+		{"0.000000000000000000001", 2, 0, decimal.ErrInexact},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, _ := decimal.NewDecimalFromString(tc.strVal)
+		result, err := d.ToMinorUnits(tc.exponent)
+		assert.ErrorIs(err, tc.err, "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.result, result, "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestIntegerConversions(t *testing.T) {
+	tests := []struct {
+		strVal   string
+		int64Val int64
+		int64Err error
+		int32Val int32
+		int32Err error
+		uint64   uint64
+		uintErr  error
+	}{
+		{"42", 42, nil, 42, nil, 42, nil},
+		{"42.000", 42, nil, 42, nil, 42, nil},
+		{"-42", -42, nil, -42, nil, 0, decimal.ErrOverflow},
+		{"42.5", 0, decimal.ErrInexact, 0, decimal.ErrInexact, 0, decimal.ErrInexact},
+		{"-0.5", 0, decimal.ErrInexact, 0, decimal.ErrInexact, 0, decimal.ErrInexact},
+		{"0.00", 0, nil, 0, nil, 0, nil},
+		{"2147483647", 2147483647, nil, math.MaxInt32, nil, 2147483647, nil},
+		{"2147483648", 2147483648, nil, 0, decimal.ErrOverflow, 2147483648, nil},
+		{"-2147483648", -2147483648, nil, math.MinInt32, nil, 0, decimal.ErrOverflow},
+		{"-2147483649", -2147483649, nil, 0, decimal.ErrOverflow, 0, decimal.ErrOverflow},
+		{"9223372036854775808", 0, decimal.ErrOverflow, 0, decimal.ErrOverflow, 9223372036854775808, nil},
+		{"18446744073709551615", 0, decimal.ErrOverflow, 0, decimal.ErrOverflow, math.MaxUint64, nil},
+		{"18446744073709551616", 0, decimal.ErrOverflow, 0, decimal.ErrOverflow, 0, decimal.ErrOverflow},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, _ := decimal.NewDecimalFromString(tc.strVal)
+		int64Val, err := d.Int64()
+		assert.ErrorIs(err, tc.int64Err, "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.int64Val, int64Val, "Test No: %d - Should be equal", testNo+1)
+		int32Val, err := d.Int32()
+		assert.ErrorIs(err, tc.int32Err, "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.int32Val, int32Val, "Test No: %d - Should be equal", testNo+1)
+		uint64Val, err := d.Uint64()
+		assert.ErrorIs(err, tc.uintErr, "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.uint64, uint64Val, "Test No: %d - Should be equal", testNo+1)
+	}
+	// Rounding to precision 0 first gives a rounded integer
+	d, _ := decimal.NewDecimalFromString("42.5")
+	rounded, err := d.Round(0, decimal.RoundHalfEven).Int64()
+	assert.Nil(err)
+	assert.Equal(int64(42), rounded)
+	_, err = d.Int64()
+	assert.Equal("convert decimal 42.5 to int64: inexact", err.Error())
+}
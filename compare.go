@@ -0,0 +1,115 @@
+package decimal
+
+import "math/bits"
+
+// Cmp - Compares a decimal to another decimal and returns -1 if the decimal is less than the other,
+// 0 if they are equal and 1 if it is greater. Decimals are compared by value regardless of their
+// precision, so 1.5 with precision 1 is equal to 1.50 with precision 2
+func (d Decimal) Cmp(other Decimal) int {
+	precision := maxPrecision(d.precision, other.precision)
+	a, okA := d.toInt()
+	b, okB := other.toInt()
+	if !okA || !okB || precision > maxSupportedPrecision {
+		return d.bigIntAt(precision).Cmp(other.bigIntAt(precision))
+	}
+	if (a < 0) != (b < 0) {
+		if a < 0 {
+			return -1
+		}
+		return 1
+	}
+	// Both decimals have the same sign, so compare their magnitudes at the largest precision and
+	// reverse the result for negative decimals
+	c := cmpScaled(absUint64(a), precision-d.precision, absUint64(b), precision-other.precision)
+	if a < 0 {
+		return -c
+	}
+	return c
+}
+
+// Equal - Returns true if the decimal is equal to another decimal regardless of their precision
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// LessThan - Returns true if the decimal is less than another decimal
+func (d Decimal) LessThan(other Decimal) bool {
+	return d.Cmp(other) < 0
+}
+
+// LessThanOrEqual - Returns true if the decimal is less than or equal to another decimal
+func (d Decimal) LessThanOrEqual(other Decimal) bool {
+	return d.Cmp(other) <= 0
+}
+
+// GreaterThan - Returns true if the decimal is greater than another decimal
+func (d Decimal) GreaterThan(other Decimal) bool {
+	return d.Cmp(other) > 0
+}
+
+// GreaterThanOrEqual - Returns true if the decimal is greater than or equal to another decimal
+func (d Decimal) GreaterThanOrEqual(other Decimal) bool {
+	return d.Cmp(other) >= 0
+}
+
+// IsPositive - Returns true if decimal is greater than zero
+func (d Decimal) IsPositive() bool {
+	return !d.negative && !d.IsZero()
+}
+
+// Sign - Returns -1 if decimal is negative, 0 if it is zero and 1 if it is positive
+func (d Decimal) Sign() int {
+	if d.IsZero() {
+		return 0
+	}
+	if d.negative {
+		return -1
+	}
+	return 1
+}
+
+// Min - Returns the smallest of the decimals. If more than one decimal has the smallest value, the
+// first of them is returned. Min panics if no decimals are given
+func Min(decimals ...Decimal) Decimal {
+	if len(decimals) == 0 {
+		panic("decimal: Min called without decimals")
+	}
+	smallest := decimals[0]
+	for _, d := range decimals[1:] {
+		if d.LessThan(smallest) {
+			smallest = d
+		}
+	}
+	return smallest
+}
+
+// Max - Returns the largest of the decimals. If more than one decimal has the largest value, the
+// first of them is returned. Max panics if no decimals are given
+func Max(decimals ...Decimal) Decimal {
+	if len(decimals) == 0 {
+		panic("decimal: Max called without decimals")
+	}
+	largest := decimals[0]
+	for _, d := range decimals[1:] {
+		if d.GreaterThan(largest) {
+			largest = d
+		}
+	}
+	return largest
+}
+
+// cmpScaled - Compares a * 10^expA to b * 10^expB using 128 bit products, so that aligning the
+// precisions of two decimals can't overflow
+func cmpScaled(a uint64, expA uint, b uint64, expB uint) int {
+	pa, _ := pow10(expA)
+	pb, _ := pow10(expB)
+	hiA, loA := bits.Mul64(a, uint64(pa))
+	hiB, loB := bits.Mul64(b, uint64(pb))
+	switch {
+	case hiA < hiB || (hiA == hiB && loA < loB):
+		return -1
+	case hiA > hiB || (hiA == hiB && loA > loB):
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,109 @@
+package decimal_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/petrossordinas/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmp(t *testing.T) {
+	tests := []struct {
+		intVal1    int64
+		precision1 uint
+		intVal2    int64
+		precision2 uint
+		result     int
+	}{
+		{15, 1, 150, 2, 0},
+		{15, 1, 151, 2, -1},
+		{151, 2, 15, 1, 1},
+		{-15, 1, -150, 2, 0},
+		{-15, 1, -151, 2, 1},
+		{-151, 2, -15, 1, -1},
+		{-1, 2, 1, 2, -1},
+		{1, 2, -1, 2, 1},
+		{0, 0, 0, 18, 0},
+		{0, 3, -1, 18, 1},
+		{math.MaxInt64, 0, math.MaxInt64, 18, 1},
+		{math.MinInt64, 0, math.MinInt64, 18, -1},
+		{math.MaxInt64, 18, 9, 0, 1},
+		{math.MaxInt64, 18, 10, 0, -1},
+		{10000000000000000, 16, 1, 0, 0},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1 := decimal.NewDecimal(tc.intVal1, tc.precision1)
+		d2 := decimal.NewDecimal(tc.intVal2, tc.precision2)
+		assert.Equal(tc.result, d1.Cmp(*d2), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(-tc.result, d2.Cmp(*d1), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.result == 0, d1.Equal(*d2), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.result < 0, d1.LessThan(*d2), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.result <= 0, d1.LessThanOrEqual(*d2), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.result > 0, d1.GreaterThan(*d2), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.result >= 0, d1.GreaterThanOrEqual(*d2), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestIsPositive(t *testing.T) {
+	tests := []struct {
+		intVal    int64
+		precision uint
+		result    bool
+	}{
+		{2456, 2, true},
+		{-12357, 3, false},
+		{0, 2, false},
+		{1, 4, true},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d := decimal.NewDecimal(tc.intVal, tc.precision)
+		assert.Equal(tc.result, d.IsPositive(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		intVal    int64
+		precision uint
+		result    int
+	}{
+		{2456, 2, 1},
+		{-12357, 3, -1},
+		{0, 2, 0},
+		{-1, 4, -1},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d := decimal.NewDecimal(tc.intVal, tc.precision)
+		assert.Equal(tc.result, d.Sign(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	tests := []struct {
+		strVals []string
+		min     string
+		max     string
+	}{
+		{[]string{"1.5"}, "1.5", "1.5"},
+		{[]string{"1.5", "1.49", "1.501"}, "1.49", "1.501"},
+		{[]string{"-3", "2.75", "-3.01", "0"}, "-3.01", "2.75"},
+		{[]string{"1.50", "1.5", "1.500"}, "1.50", "1.50"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		decimals := make([]decimal.Decimal, len(tc.strVals))
+		for i, s := range tc.strVals {
+			d, err := decimal.NewDecimalFromString(s)
+			assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+			decimals[i] = *d
+		}
+		assert.Equal(tc.min, decimal.Min(decimals...).ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.max, decimal.Max(decimals...).ToString(), "Test No: %d - Should be equal", testNo+1)
+	}
+	assert.Panics(func() { decimal.Min() })
+	assert.Panics(func() { decimal.Max() })
+}
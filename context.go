@@ -0,0 +1,268 @@
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ScaleMode - Determines the precision of the result of an arithmetic operation in a Context
+type ScaleMode int
+
+const (
+	// ScaleMax gives the result the precision of the operand with the largest precision, like
+	// Multiply and Divide, so 0.05 × 0.05 is 0.00. This is the scale mode used when none is given.
+	ScaleMax ScaleMode = iota
+	// ScaleSum gives the result the sum of the precisions of the operands, so multiplication is
+	// exact and 0.05 × 0.05 is 0.0025
+	ScaleSum
+	// ScaleFixed gives the result the precision of the context, so 1 / 3 with precision 4 is 0.3333
+	ScaleFixed
+)
+
+// Condition - A set of exceptional conditions that can occur in the arithmetic operations of a Context,
+// modelled on the conditions of the General Decimal Arithmetic specification. DigitsExceeded isn't
+// part of the specification
+type Condition uint
+
+const (
+	// Inexact is set when the result was rounded and the discarded digits were not all zero
+	Inexact Condition = 1 << iota
+	// Rounded is set when digits were discarded from the result, even if they were all zero
+	Rounded
+	// Clamped is the condition of the specification set when the exponent of a result is clamped to
+	// the limits of the context. Decimals have no exponent limits, so it is never set
+	Clamped
+	// Overflow is set when the whole part of the result has more digits than the MaxWholeDigits of
+	// the context
+	Overflow
+	// DivisionByZero is set when dividing by zero
+	DivisionByZero
+	// InvalidOperation is set when converting a value that isn't a number, like NaN or infinity
+	InvalidOperation
+	// DigitsExceeded is set when the result has more significant digits than the Digits of the
+	// context, because digits of the whole part are never discarded
+	DigitsExceeded
+)
+
+// conditionNames - The names of the conditions in the order of their bits
+var conditionNames = []string{"inexact", "rounded", "clamped", "overflow", "division by zero", "invalid operation", "digits exceeded"}
+
+// String - Returns the names of the conditions separated by commas, e.g. "inexact, rounded"
+func (c Condition) String() string {
+	names := make([]string, 0, len(conditionNames))
+	for i, name := range conditionNames {
+		if c&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// Error - Returns the names of the conditions, so that the trapped conditions of an operation can
+// be returned as an error
+func (c Condition) Error() string {
+	return c.String()
+}
+
+// Is - Returns true if the target is a condition contained in c, or the error matching Inexact,
+// Overflow or DivisionByZero, so errors.Is(err, Inexact) and errors.Is(err, ErrInexact) both work
+func (c Condition) Is(target error) bool {
+	switch target {
+	case ErrInexact:
+		return c&Inexact != 0
+	case ErrOverflow:
+		return c&Overflow != 0
+	case ErrDivisionByZero:
+		return c&DivisionByZero != 0
+	}
+	t, ok := target.(Condition)
+	return ok && t != 0 && c&t == t
+}
+
+// alwaysTrapped - The conditions that return an error whether trapped or not, since a decimal can't
+// represent infinity or a value that isn't a number
+const alwaysTrapped = Overflow | DivisionByZero | InvalidOperation
+
+// Context - Determines the precision and rounding of the result of arithmetic operations, modelled
+// on the General Decimal Arithmetic specification. Every operation adds the conditions that occurred
+// to Flags, so that code can detect rounding after a series of operations, and returns an error if any
+// of them is trapped. The zero value gives the same results as Add, Subtract, Multiply and Divide and
+// traps no conditions. For example, an exchange rate can be applied with six decimal places and
+// banker's rounding, failing if anything is lost, using
+//
+//	ctx := Context{Scale: ScaleFixed, Precision: 6, Rounding: RoundHalfEven, Traps: Inexact}
+//	converted, err := ctx.Multiply(amount, rate)
+//
+// A Context must not be used by more than one goroutine at a time, since operations update its flags.
+type Context struct {
+	// Scale determines the precision of the result
+	Scale ScaleMode
+	// Precision is the precision of the result when Scale is ScaleFixed
+	Precision uint
+	// Digits is the largest number of significant digits of the result, or 0 for no limit. Fraction
+	// digits beyond the limit are rounded, but digits of the whole part are always kept, so 1000 / 3
+	// with Precision 10 and Digits 5 is 333.33 and 1234567 with Digits 5 is still 1234567, which sets
+	// DigitsExceeded.
+	Digits uint
+	// MaxWholeDigits is the largest number of digits of the whole part of the result, or 0 for no
+	// limit. Results with more digits set Overflow.
+	MaxWholeDigits uint
+	// Rounding is the rounding mode used when digits beyond the precision of the result are discarded
+	Rounding RoundingMode
+	// Traps is the set of conditions that make an operation return an error. Overflow,
+	// DivisionByZero and InvalidOperation always do.
+	Traps Condition
+	// Flags is the set of conditions that occurred since the flags were last cleared
+	Flags Condition
+}
+
+// Add - Adds a decimal to another decimal, rounding the sum to the precision and significant digits
+// of the context
+func (c *Context) Add(d, other Decimal) (Decimal, error) {
+	precision := maxPrecision(d.precision, other.precision)
+	num := new(big.Int).Add(d.bigIntAt(precision), other.bigIntAt(precision))
+	return c.result("add", num, bigPow10(precision), precision, c.precision(d, other))
+}
+
+// Subtract - Subtracts a decimal from another decimal, rounding the difference to the precision and
+// significant digits of the context
+func (c *Context) Subtract(d, other Decimal) (Decimal, error) {
+	precision := maxPrecision(d.precision, other.precision)
+	num := new(big.Int).Sub(d.bigIntAt(precision), other.bigIntAt(precision))
+	return c.result("subtract", num, bigPow10(precision), precision, c.precision(d, other))
+}
+
+// Multiply - Multiplies a decimal with another decimal, rounding the product to the precision and
+// significant digits of the context
+func (c *Context) Multiply(d, factor Decimal) (Decimal, error) {
+	num := new(big.Int).Mul(d.bigInt(), factor.bigInt())
+	exact := d.precision + factor.precision
+	return c.result("multiply", num, bigPow10(exact), exact, c.precision(d, factor))
+}
+
+// Divide - Divides a decimal with another decimal, rounding the quotient to the precision and
+// significant digits of the context. Dividing by zero sets DivisionByZero and returns an error
+// matching ErrDivisionByZero
+func (c *Context) Divide(d, divisor Decimal) (Decimal, error) {
+	if divisor.IsZero() {
+		return c.fail("divide", DivisionByZero)
+	}
+	// d / divisor = (a / 10^pa) / (b / 10^pb) = (a * 10^pb) / (b * 10^pa)
+	num := new(big.Int).Mul(d.bigInt(), bigPow10(divisor.precision))
+	den := new(big.Int).Mul(divisor.bigInt(), bigPow10(d.precision))
+	return c.result("divide", num, den, 0, c.precision(d, divisor))
+}
+
+// NewFromFloat - Creates a new decimal from a float, rounded to the precision and significant digits
+// of the context. The float is taken as the shortest decimal that converts back to it, as printed by
+// strconv.FormatFloat(amount, 'g', -1, 64), so 0.1 is exactly 0.1. Unless the scale mode is ScaleFixed,
+// the decimal has the precision of that shortest decimal. NaN and infinities set InvalidOperation and
+// return an error.
+func (c *Context) NewFromFloat(amount float64) (Decimal, error) {
+	n, err := parseFloat(amount)
+	if err != nil {
+		return c.fail("convert float", InvalidOperation)
+	}
+	exact := uint(0)
+	if n.scale > 0 {
+		exact = uint(n.scale)
+	}
+	d, _ := n.toDecimal(exact, RoundHalfUp)
+	precision := exact
+	if c.Scale == ScaleFixed {
+		precision = c.Precision
+	}
+	return c.result("convert float", d.bigInt(), bigPow10(exact), exact, precision)
+}
+
+// precision - Returns the precision of the result of an operation on two decimals
+func (c *Context) precision(d, other Decimal) uint {
+	switch c.Scale {
+	case ScaleSum:
+		return d.precision + other.precision
+	case ScaleFixed:
+		return c.Precision
+	default:
+		return maxPrecision(d.precision, other.precision)
+	}
+}
+
+// result - Returns num / den as a decimal with the given precision like quotient, and records the
+// conditions that occurred. exact is the precision at which num / den is known to be exact, or 0 if
+// it isn't known, so that discarding digits sets Rounded even if they are all zero.
+func (c *Context) result(op string, num, den *big.Int, exact, precision uint) (Decimal, error) {
+	result, conditions := c.quotient(num, den, precision)
+	if result.precision < exact {
+		conditions |= Rounded
+	}
+	if c.MaxWholeDigits > 0 && result.numDigits() > c.MaxWholeDigits+result.precision {
+		conditions |= Overflow
+	}
+	if conditions&(c.Traps|alwaysTrapped) != 0 {
+		return c.fail(op, conditions)
+	}
+	c.Flags |= conditions
+	return result, nil
+}
+
+// fail - Records the conditions and returns an error with the ones that are trapped
+func (c *Context) fail(op string, conditions Condition) (Decimal, error) {
+	c.Flags |= conditions
+	return Decimal{}, fmt.Errorf("%s decimal: %w", op, conditions&(c.Traps|alwaysTrapped))
+}
+
+// quotient - Returns num / den as a decimal with the given precision, reduced as needed to respect
+// the significant digits of the context, and the conditions that occurred. The value is always
+// rounded once from the exact quotient.
+func (c *Context) quotient(num, den *big.Int, precision uint) (Decimal, Condition) {
+	result := c.round(num, den, precision)
+	for c.Digits > 0 && result.numDigits() > c.Digits {
+		if result.precision == 0 {
+			return result, DigitsExceeded | inexact(num, den, 0)
+		}
+		// Rounding may carry into a new digit, e.g. 9.996 to 10.00, so check the result again
+		if excess := result.numDigits() - c.Digits; excess < result.precision {
+			precision = result.precision - excess
+		} else {
+			precision = 0
+		}
+		result = c.round(num, den, precision)
+	}
+	return result, inexact(num, den, precision)
+}
+
+// inexact - Returns Inexact and Rounded if num / den has non-zero digits beyond the precision
+func inexact(num, den *big.Int, precision uint) Condition {
+	scaled := new(big.Int).Mul(num, bigPow10(precision))
+	if scaled.Rem(scaled, den).Sign() != 0 {
+		return Inexact | Rounded
+	}
+	return 0
+}
+
+// round - Returns num / den as a decimal with the given precision, rounded using the rounding mode
+// of the context
+func (c *Context) round(num, den *big.Int, precision uint) Decimal {
+	if num.IsInt64() && den.IsInt64() {
+		if p, ok := pow10(precision); ok {
+			if value, ok := mulDivRound(num.Int64(), p, den.Int64(), c.Rounding); ok {
+				return *NewDecimal(value, precision)
+			}
+		}
+	}
+	scaled := new(big.Int).Mul(num, bigPow10(precision))
+	return *newDecimalFromBigInt(bigDivRound(scaled, den, c.Rounding), precision)
+}
+
+// numDigits - Returns the number of digits of the coefficient of the decimal, which is 1 for zero
+func (d Decimal) numDigits() uint {
+	if d.bigCoefficient != nil {
+		return uint(len(d.bigCoefficient.String()))
+	}
+	n := uint(1)
+	for c := d.coefficient; c >= 10; c /= 10 {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,195 @@
+package decimal_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/petrossordinas/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextMultiply(t *testing.T) {
+	tests := []struct {
+		ctx    decimal.Context
+		d1     string
+		d2     string
+		result string
+	}{
+		{decimal.Context{}, "0.05", "0.05", "0.00"},
+		{decimal.Context{Scale: decimal.ScaleSum}, "0.05", "0.05", "0.0025"},
+		{decimal.Context{Scale: decimal.ScaleSum}, "-1.5", "2.25", "-3.375"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 4}, "1.2345", "1.1", "1.3580"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 4, Rounding: decimal.RoundDown}, "1.2345", "1.1", "1.3579"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 2, Rounding: decimal.RoundHalfEven}, "100.00", "1.08125", "108.12"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 6}, "2", "3", "6.000000"},
+		{decimal.Context{Scale: decimal.ScaleSum, Digits: 4}, "1.2345", "1.1", "1.358"},
+		{decimal.Context{Scale: decimal.ScaleSum}, "123456789012345678901234567890", "0.5", "61728394506172839450617283945.0"},
+		{decimal.Context{Scale: decimal.ScaleSum}, "0.123456789012", "0.123456789012", "0.015241578753153483936144"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1, _ := decimal.NewDecimalFromString(tc.d1)
+		d2, _ := decimal.NewDecimalFromString(tc.d2)
+		result, err := tc.ctx.Multiply(*d1, *d2)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(tc.result, result.ToString(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestContextDivide(t *testing.T) {
+	tests := []struct {
+		ctx    decimal.Context
+		d1     string
+		d2     string
+		result string
+	}{
+		{decimal.Context{}, "1", "3", "0"},
+		{decimal.Context{}, "1.00", "3", "0.33"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 10}, "1", "3", "0.3333333333"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 10}, "2", "3", "0.6666666667"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 10, Rounding: decimal.RoundDown}, "2", "3", "0.6666666666"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 10}, "-2", "3", "-0.6666666667"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 2}, "10.5", "0.0025", "4200.00"},
+		{decimal.Context{Scale: decimal.ScaleSum}, "1.5", "0.25", "6.000"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 20, Digits: 10}, "1", "3", "0.3333333333"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 20, Digits: 10}, "1000", "3", "333.3333333"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 20, Digits: 3}, "9.9996", "1", "10.0"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 2, Digits: 5}, "12345678901234", "1", "12345678901234"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 30}, "1", "7", "0.142857142857142857142857142857"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1, _ := decimal.NewDecimalFromString(tc.d1)
+		d2, _ := decimal.NewDecimalFromString(tc.d2)
+		result, err := tc.ctx.Divide(*d1, *d2)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(tc.result, result.ToString(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestContextAddSubtract(t *testing.T) {
+	tests := []struct {
+		ctx        decimal.Context
+		d1         string
+		d2         string
+		sum        string
+		difference string
+	}{
+		{decimal.Context{}, "1.25", "2.5", "3.75", "-1.25"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 1}, "1.25", "2.5", "3.8", "-1.3"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 1, Rounding: decimal.RoundHalfEven}, "1.25", "2.5", "3.8", "-1.2"},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 4}, "1.25", "2.5", "3.7500", "-1.2500"},
+		{decimal.Context{Digits: 3}, "99.95", "0.01", "100", "99.9"},
+		{decimal.Context{}, "123456789012345678901234567890", "0.1", "123456789012345678901234567890.1", "123456789012345678901234567889.9"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1, _ := decimal.NewDecimalFromString(tc.d1)
+		d2, _ := decimal.NewDecimalFromString(tc.d2)
+		sum, err := tc.ctx.Add(*d1, *d2)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(tc.sum, sum.ToString(), "Test No: %d - Should be equal", testNo+1)
+		difference, err := tc.ctx.Subtract(*d1, *d2)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(tc.difference, difference.ToString(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestContextFlags(t *testing.T) {
+	tests := []struct {
+		ctx   decimal.Context
+		op    string
+		d1    string
+		d2    string
+		flags decimal.Condition
+	}{
+		{decimal.Context{}, "add", "1.25", "2.5", 0},
+		{decimal.Context{}, "multiply", "1.50", "2", 0},
+		{decimal.Context{}, "multiply", "1.50", "2.0", decimal.Rounded},
+		{decimal.Context{}, "multiply", "1.55", "2.5", decimal.Rounded | decimal.Inexact},
+		{decimal.Context{Scale: decimal.ScaleSum}, "multiply", "1.55", "2.5", 0},
+		{decimal.Context{}, "divide", "6.00", "2", 0},
+		{decimal.Context{}, "divide", "1", "3", decimal.Rounded | decimal.Inexact},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 1}, "add", "1.20", "2.30", decimal.Rounded},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 1}, "add", "1.25", "2.5", decimal.Rounded | decimal.Inexact},
+		{decimal.Context{Digits: 3}, "add", "1234", "1", decimal.DigitsExceeded},
+		{decimal.Context{Digits: 3}, "add", "1234", "0.5", decimal.DigitsExceeded | decimal.Rounded | decimal.Inexact},
+		{decimal.Context{MaxWholeDigits: 3}, "add", "999.99", "0.01", decimal.Overflow},
+		{decimal.Context{MaxWholeDigits: 3}, "add", "999.99", "-1000", 0},
+		{decimal.Context{}, "divide", "1", "0.00", decimal.DivisionByZero},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1, _ := decimal.NewDecimalFromString(tc.d1)
+		d2, _ := decimal.NewDecimalFromString(tc.d2)
+		var err error
+		switch tc.op {
+		case "add":
+			_, err = tc.ctx.Add(*d1, *d2)
+		case "multiply":
+			_, err = tc.ctx.Multiply(*d1, *d2)
+		case "divide":
+			_, err = tc.ctx.Divide(*d1, *d2)
+		}
+		assert.Equal(tc.flags, tc.ctx.Flags, "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.flags&(decimal.Overflow|decimal.DivisionByZero) != 0, err != nil, "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestContextTraps(t *testing.T) {
+	assert := assert.New(t)
+	ctx := decimal.Context{Traps: decimal.Inexact}
+	product, err := ctx.Multiply(*decimal.NewDecimal(150, 2), *decimal.NewDecimal(20, 1))
+	assert.Nil(err)
+	assert.Equal("3.00", product.ToString())
+	_, err = ctx.Divide(*decimal.NewDecimal(1, 0), *decimal.NewDecimal(3, 0))
+	assert.ErrorIs(err, decimal.Inexact)
+	assert.ErrorIs(err, decimal.ErrInexact)
+	assert.NotErrorIs(err, decimal.Rounded)
+	assert.Equal("divide decimal: inexact", err.Error())
+	// Flags accumulate until they are cleared
+	assert.Equal(decimal.Inexact|decimal.Rounded, ctx.Flags)
+	_, err = ctx.Divide(*decimal.NewDecimal(1, 0), *decimal.NewDecimal(0, 0))
+	assert.ErrorIs(err, decimal.ErrDivisionByZero)
+	assert.ErrorIs(err, decimal.DivisionByZero)
+	assert.Equal(decimal.Inexact|decimal.Rounded|decimal.DivisionByZero, ctx.Flags)
+
+	ctx = decimal.Context{MaxWholeDigits: 2}
+	_, err = ctx.Add(*decimal.NewDecimal(99, 0), *decimal.NewDecimal(1, 0))
+	assert.ErrorIs(err, decimal.ErrOverflow)
+	assert.Equal("overflow", decimal.Overflow.String())
+	assert.Equal("inexact, rounded, digits exceeded", (decimal.Inexact | decimal.Rounded | decimal.DigitsExceeded).String())
+	assert.Equal("clamped", decimal.Clamped.String())
+}
+
+func TestContextNewFromFloat(t *testing.T) {
+	tests := []struct {
+		ctx    decimal.Context
+		amount float64
+		result string
+		flags  decimal.Condition
+	}{
+		{decimal.Context{}, 0.1, "0.1", 0},
+		{decimal.Context{}, -2.675, "-2.675", 0},
+		{decimal.Context{}, 1e21, "1000000000000000000000", 0},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 2}, 2.675, "2.68", decimal.Rounded | decimal.Inexact},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 2, Rounding: decimal.RoundHalfEven}, 2.665, "2.66", decimal.Rounded | decimal.Inexact},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 2}, 2.5, "2.50", 0},
+		{decimal.Context{Scale: decimal.ScaleFixed, Precision: 2}, 2.501, "2.50", decimal.Rounded | decimal.Inexact},
+		{decimal.Context{Digits: 5}, 3.14159265358979, "3.1416", decimal.Rounded | decimal.Inexact},
+		{decimal.Context{}, 5e-324, "0." + strings.Repeat("0", 323) + "5", 0},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, err := tc.ctx.NewFromFloat(tc.amount)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(tc.result, d.ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.flags, tc.ctx.Flags, "Test No: %d - Should be equal", testNo+1)
+	}
+	ctx := decimal.Context{}
+	for _, amount := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := ctx.NewFromFloat(amount)
+		assert.ErrorIs(err, decimal.InvalidOperation)
+	}
+}
@@ -4,182 +4,359 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
 )
 
+// The decimal point and thousand separator of DefaultFormatter, used by ToStringFormatted
 const (
 	DEFAULT_DECIMAL_POINT      = ','
 	DEFAULT_THOUSAND_SEPARATOR = '.'
 )
 
+// Decimal - A decimal number stored in sign-magnitude form as the sign and the absolute value of
+// decimal * 10^precision. For example, -123.45 is stored as negative = true, coefficient = 12345
+// and precision = 2. Coefficients that don't fit in an uint64 are stored in bigCoefficient instead,
+// so decimals have arbitrary precision. Operations use int64 arithmetic and switch to math/big
+// only when a result would overflow. A decimal holds no formatting settings, which are kept by a
+// Formatter instead. The zero value is the decimal 0 with precision 0 and is ready to use, so a
+// Decimal can be a struct field without a constructor.
 type Decimal struct {
-	whole             int64
-	fraction          int64
-	precision         uint
-	decimalPoint      byte
-	thousandSeparator byte
+	negative       bool
+	coefficient    uint64
+	bigCoefficient *big.Int
+	precision      uint
 }
 
 // NewDecimal - Creates a new decimal from an integer
 // Amount must be an integer representing the decimal as decimal * 10^-1*precision.
 // For example, if the decimal is 123.45, amount must be 12345 and precision 2
 func NewDecimal(amount int64, precision uint) *Decimal {
-	// Convert amount to float by multiplying it with 10 to the power of negative precision
-	floatAmount := float64(amount) * math.Pow10(int(precision)*-1)
-	// Split the float into its whole and fractional part
-	w, f := math.Modf(floatAmount)
-	// Determine the sign of the number. We need this for rounding the fractional part below
-	sign := 1.0
-	if w < 0 || f < 0 {
-		sign = -1.0
-	}
-	// Rounder is used to round the fraction part. We multiply 5 by sign by 10 to the power of
-	// negative precision incremented by one. If the fraction is 0.456 and the precision is 2
-	// the fraction will be converted to an integer as 46
-	rounder := sign * (5 * math.Pow10(int(precision+1)*-1))
 	return &Decimal{
-		whole:             int64(w),
-		fraction:          int64((f + rounder) * math.Pow10(int(precision))),
-		precision:         precision,
-		decimalPoint:      DEFAULT_DECIMAL_POINT,
-		thousandSeparator: DEFAULT_THOUSAND_SEPARATOR,
+		negative:    amount < 0,
+		coefficient: absUint64(amount),
+		precision:   precision,
 	}
 }
 
 // NewDecimalFromFloat - Creates a new decimal from a float
 // Amount must be a float and precision the desired width of the fraction part.
-// For example, amount = 123.45 and precision 2
+// For example, amount = 123.45 and precision 2. The float is taken as the shortest decimal that
+// converts back to it, so 1.005 is 1.005 and not 1.00499999999999989..., and rounded half away
+// from zero to the precision once, so 1.005 with precision 2 is 1.01. It panics for NaN and
+// infinities like NewDecimalFromFloatWithRounding
 func NewDecimalFromFloat(amount float64, precision uint) *Decimal {
-	// Split the float into its whole and fractional part
-	w, f := math.Modf(amount)
-	nf := f * math.Pow10(int(precision+2))
-	rnf := math.Round(nf)
-	f = rnf * math.Pow10(int(precision+2)*-1)
-	// Determine the sign of the number. We need this for rounding the fractional part below
-	sign := 1.0
-	if w < 0 || f < 0 {
-		sign = -1.0
-	}
-	// Rounder is used to round the fraction part. We multiply 5 by sign by 10 to the power of
-	// negative precision incremented by one. If the fraction is 0.456 and the precision is 2
-	// the fraction will be converted to an integer as 46
-	rounder := sign * (5 * math.Pow10(int(precision+1)*-1))
-	return &Decimal{
-		whole:             int64(w),
-		fraction:          int64((f + rounder) * math.Pow10(int(precision))),
-		precision:         uint(precision),
-		decimalPoint:      DEFAULT_DECIMAL_POINT,
-		thousandSeparator: DEFAULT_THOUSAND_SEPARATOR,
+	return NewDecimalFromFloatWithRounding(amount, precision, RoundHalfUp)
+}
+
+// NewDecimalFromFloatWithRounding - Creates a new decimal from a float like NewDecimalFromFloat,
+// rounding the fraction part to the precision using the rounding mode.
+// For example, amount = 2.675, precision 2 and RoundDown result in 2.67. NaN, infinities and
+// precisions larger than 10000 can't be represented, so it panics with an error matching
+// ErrOutOfRange for them, use NewDecimalFromFloatChecked to get the error instead
+func NewDecimalFromFloatWithRounding(amount float64, precision uint, mode RoundingMode) *Decimal {
+	d, err := NewDecimalFromFloatChecked(amount, precision, mode)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// NewDecimalFromFloatChecked - Creates a new decimal from a float like NewDecimalFromFloatWithRounding.
+// It returns an error matching ErrOutOfRange for NaN, infinities and precisions larger than 10000
+func NewDecimalFromFloatChecked(amount float64, precision uint, mode RoundingMode) (*Decimal, error) {
+	n, err := parseFloat(amount)
+	if err != nil {
+		return nil, err
 	}
+	return n.toDecimal(precision, mode)
+}
+
+// NewDecimalFromFloatExact - Creates a new decimal with the exact value of the binary float, with
+// as many digits as needed, so 0.1 is 0.1000000000000000055511151231257827021181583404541015625 and
+// 0.5 is 0.5. It returns ErrOutOfRange for NaN and infinities
+func NewDecimalFromFloatExact(amount float64) (*Decimal, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return nil, fmt.Errorf("convert float %v: %w", amount, ErrOutOfRange)
+	}
+	r := new(big.Rat).SetFloat64(amount)
+	// The denominator of a float is a power of two 2^k, so the float is num * 5^k / 10^k
+	k := uint(r.Denom().BitLen() - 1)
+	coefficient := new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(k)), nil)
+	return newDecimalFromBigInt(coefficient.Mul(coefficient, r.Num()), k), nil
+}
+
+// parseFloat - Parses the shortest decimal that converts back to the float, as printed by
+// strconv.FormatFloat(amount, 'g', -1, 64). It returns ErrOutOfRange for NaN and infinities
+func parseFloat(amount float64) (number, error) {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return number{}, fmt.Errorf("convert float %v: %w", amount, ErrOutOfRange)
+	}
+	return parseNumber(strconv.FormatFloat(amount, 'g', -1, 64))
 }
 
 // ToInt - Returns the integer representation of decimal multiplied by 10^precision
 // For example, for a decimal with whole part = 123 and fraction 45, the return
-// value will be 12345
+// value will be 12345. The result is undefined if it doesn't fit in an int64, use
+// ToMinorUnits(d.GetPrecision()) to get an error instead
 func (d Decimal) ToInt() int64 {
-	w := d.whole * int64(math.Pow10(int(d.precision)))
-	return w + d.fraction
+	value, _ := d.toInt()
+	return value
+}
+
+// toInt - Returns the integer representation of decimal like ToInt and whether it fits in an int64
+func (d Decimal) toInt() (int64, bool) {
+	value, ok := toInt64(d.coefficient, d.negative)
+	return value, ok && d.bigCoefficient == nil
 }
 
 // ToFloat - Returns a float representation of decimal, with number of floating points
 // equal to decimal precision. For example, for a decimal with whole part = 123 and fraction 45
-// the return value will be 123.45
+// the return value will be 123.45. The float is the one nearest to the decimal
 func (d Decimal) ToFloat() float64 {
-	f := float64(d.fraction) * math.Pow10(int(d.precision)*-1)
-	return float64(d.whole) + f
+	f, _ := strconv.ParseFloat(d.ToString(), 64)
+	return f
 }
 
 // ToString - Returns the decimal as a string. For a decimal with whole part = 123 and fraction 45,
 // the return value will be '123.45'
 func (d Decimal) ToString() string {
-	f := d.ToFloat()
-	return fmt.Sprintf("%."+fmt.Sprintf("%d", d.precision)+"f", f)
+	var buf [32]byte
+	return string(d.AppendString(buf[:0]))
 }
 
-// ToStringFormatted - Returns the decimal as a string, formatted with thousands separator and decimal
-// point. For a decimal with decimal point '.' and thousands separator ',' and value 2334599 the result
-// will be '23,345.99'
-func (d Decimal) ToStringFormatted() string {
-	// Count the number of times the whole part of the decimal is divisible by 1000
-	t := 0
-	for r := d.whole / 1000; r > 0; r /= 1000 {
-		t++
-	}
-	// Convert the whole part to string
-	fs := fmt.Sprintf("%d", d.whole)
-	// Add the thousand seperator every three characters of fs
-	for i := 1; i <= t*3; i += 4 {
-		fs = fs[:i] + string(d.thousandSeparator) + fs[i:]
-	}
-	// Add the decimal point
-	fs += string(d.decimalPoint)
-	// Add the fractional part to the string padding zeroes to the right as required by
-	// the decimal precision.
-	fs += fmt.Sprintf("%0"+fmt.Sprintf("%d", d.precision)+"d", d.fraction)
-	return fs
-}
-
-// SetDecimalPoint - Setter for decimal point
-func (d *Decimal) SetDecimalPoint(decimalPoint byte) *Decimal {
-	d.decimalPoint = decimalPoint
-	return d
+// AppendString - Appends the decimal as a string, as returned by ToString, to dst and returns the
+// extended buffer, like strconv.AppendInt. It doesn't allocate unless dst is too small or the
+// coefficient doesn't fit in an uint64
+func (d Decimal) AppendString(dst []byte) []byte {
+	if d.IsNegative() {
+		dst = append(dst, '-')
+	}
+	return d.appendMagnitude(dst, Formatter{DecimalPoint: '.'})
 }
 
-// SetThousandSeparator - Setter for thousand separator
-func (d *Decimal) SetThousandSeparator(ts byte) *Decimal {
-	d.thousandSeparator = ts
-	return d
+// ToStringFormatted - Returns the decimal as a string formatted with DefaultFormatter, i.e. with
+// thousand separator '.' and decimal point ','. For a decimal with value 2334599 and precision 2 the
+// result will be '23.345,99'. Use a Formatter for other separators and negative styles
+func (d Decimal) ToStringFormatted() string {
+	return DefaultFormatter.Format(d)
 }
 
-// GetWhole - Getter for the whole part of the decimal
+// GetWhole - Getter for the whole part of the decimal. The whole part has the sign of the
+// decimal, so for -12.357 it is -12 and for -0.50 it is 0. The result is undefined if it
+// doesn't fit in an int64
 func (d Decimal) GetWhole() int64 {
-	return d.whole
+	whole, _ := d.parts()
+	value, _ := toInt64(whole, d.negative)
+	return value
 }
 
-// GetFraction - Getter for the fraction part of the decimal
+// GetFraction - Getter for the fraction part of the decimal. The fraction part has the sign of
+// the decimal, so for -12.357 it is -357 and for -0.50 it is -50. The result is undefined if it
+// doesn't fit in an int64
 func (d Decimal) GetFraction() int64 {
-	return d.fraction
+	_, fraction := d.parts()
+	value, _ := toInt64(fraction, d.negative)
+	return value
+}
+
+// GetPrecision - Getter for the precision of the decimal
+func (d Decimal) GetPrecision() uint {
+	return d.precision
 }
 
 // IsZero - Returns true if decimal is zero
 func (d Decimal) IsZero() bool {
-	return d.whole == 0 && d.fraction == 0
+	return d.coefficient == 0 && d.bigCoefficient == nil
 }
 
 // IsNegative - Returns true if decimal is negative
 func (d Decimal) IsNegative() bool {
-	return d.whole < 0 && d.fraction <= 0
+	return d.negative && !d.IsZero()
+}
+
+// Abs - Returns the absolute value of decimal
+func (d Decimal) Abs() Decimal {
+	d.negative = false
+	return d
+}
+
+// Neg - Returns the decimal with its sign flipped. The negation of zero is zero
+func (d Decimal) Neg() Decimal {
+	d.negative = !d.negative && !d.IsZero()
+	return d
+}
+
+// Normalize - Returns the decimal with the trailing zeros of its fraction removed, reducing its
+// precision. For example, 1.2500 with precision 4 becomes 1.25 with precision 2 and 0.000 becomes 0
+// with precision 0. Decimals with the same value have equal normalized forms by Equal and
+// reflect.DeepEqual, but not always by ==, since coefficients that don't fit in an uint64 are kept in
+// a big.Int pointer. Use Key to hash decimals or as a map key.
+func (d Decimal) Normalize() Decimal {
+	if d.bigCoefficient == nil {
+		for d.precision > 0 && d.coefficient%10 == 0 {
+			d.coefficient /= 10
+			d.precision--
+		}
+		return d
+	}
+	m := d.magnitude()
+	quotient, digit, ten := new(big.Int), new(big.Int), big.NewInt(10)
+	for d.precision > 0 {
+		if quotient.QuoRem(m, ten, digit); digit.Sign() != 0 {
+			break
+		}
+		m.Set(quotient)
+		d.precision--
+	}
+	d.setMagnitude(m)
+	return d
+}
+
+// Key - Returns the digits of the normalized decimal, e.g. "1.25" for 1.2500. Decimals with the same
+// value have the same key whatever their precision and size, so it can be hashed or used as a map key
+func (d Decimal) Key() string {
+	return d.Normalize().ToString()
 }
 
 // Add - Adds a decimal to another decimal. The resulting decimal will have
-// the precision of the decimal with the largest precision.
+// the precision of the decimal with the largest precision. The sum is computed
+// exactly on the scaled integers of both decimals.
 func (d Decimal) Add(decimalToAdd Decimal) Decimal {
-	sum := d.ToFloat() + decimalToAdd.ToFloat()
-	precision := math.Max(float64(d.precision), float64(decimalToAdd.precision))
-	return *NewDecimalFromFloat(sum, uint(precision))
+	precision := maxPrecision(d.precision, decimalToAdd.precision)
+	a, okA := d.toPrecision(precision)
+	b, okB := decimalToAdd.toPrecision(precision)
+	if sum, ok := addInt64(a, b); okA && okB && ok {
+		return *NewDecimal(sum, precision)
+	}
+	// The sum doesn't fit in an int64, so compute it with arbitrary precision
+	sum := d.bigIntAt(precision)
+	return *newDecimalFromBigInt(sum.Add(sum, decimalToAdd.bigIntAt(precision)), precision)
 }
 
 // Subtract - Subtracts a decimal from another decimal. The resulting decimal will
-// have the precision of the decimal with the largest precision.
+// have the precision of the decimal with the largest precision. The difference is
+// computed exactly on the scaled integers of both decimals.
 func (d Decimal) Subtract(decimalToSubtract Decimal) Decimal {
-	difference := d.ToFloat() - decimalToSubtract.ToFloat()
-	precision := math.Max(float64(d.precision), float64(decimalToSubtract.precision))
-	return *NewDecimalFromFloat(difference, uint(precision))
+	precision := maxPrecision(d.precision, decimalToSubtract.precision)
+	a, okA := d.toPrecision(precision)
+	b, okB := decimalToSubtract.toPrecision(precision)
+	if difference, ok := subInt64(a, b); okA && okB && ok {
+		return *NewDecimal(difference, precision)
+	}
+	// The difference doesn't fit in an int64, so compute it with arbitrary precision
+	difference := d.bigIntAt(precision)
+	return *newDecimalFromBigInt(difference.Sub(difference, decimalToSubtract.bigIntAt(precision)), precision)
 }
 
 // Multiply - Multiplies a decimal with another decimal. The resulting decimal will
-// have the precision of the decimal with the largest precision.
+// have the precision of the decimal with the largest precision. The exact product
+// of the scaled integers is rounded half away from zero to that precision.
 func (d Decimal) Multiply(factor Decimal) Decimal {
-	product := d.ToFloat() * factor.ToFloat()
-	precision := math.Max(float64(d.precision), float64(factor.precision))
-	return *NewDecimalFromFloat(product, uint(precision))
+	return d.MultiplyWithRounding(factor, RoundHalfUp)
+}
+
+// MultiplyWithRounding - Multiplies a decimal with another decimal like Multiply, rounding the
+// product to the resulting precision using the rounding mode
+func (d Decimal) MultiplyWithRounding(factor Decimal, mode RoundingMode) Decimal {
+	precision := maxPrecision(d.precision, factor.precision)
+	// The product of the scaled integers has a precision equal to the sum of both precisions,
+	// so it is divided by 10^(sum of precisions - precision)
+	exp := d.precision + factor.precision - precision
+	a, okA := d.toInt()
+	b, okB := factor.toInt()
+	divisor, okP := pow10(exp)
+	if product, ok := mulDivRound(a, b, divisor, mode); okA && okB && okP && ok {
+		return *NewDecimal(product, precision)
+	}
+	// The product doesn't fit in an int64, so compute it with arbitrary precision
+	product := d.bigInt()
+	product.Mul(product, factor.bigInt())
+	return *newDecimalFromBigInt(bigDivRound(product, bigPow10(exp), mode), precision)
 }
 
 // Divide - Divides a decimal with another decimal. The resulting decimal will
-// have the precision of the decimal with the largest precision.
+// have the precision of the decimal with the largest precision. The quotient is
+// rounded half away from zero to that precision. Divide panics if the divisor is
+// zero, use DivideChecked to get an error instead.
 func (d Decimal) Divide(divisor Decimal) Decimal {
-	quotient := d.ToFloat() / divisor.ToFloat()
-	precision := math.Max(float64(d.precision), float64(divisor.precision))
-	return *NewDecimalFromFloat(quotient, uint(precision))
+	return d.DivideWithRounding(divisor, RoundHalfUp)
+}
+
+// DivideWithRounding - Divides a decimal with another decimal like Divide, rounding the
+// quotient to the resulting precision using the rounding mode
+func (d Decimal) DivideWithRounding(divisor Decimal, mode RoundingMode) Decimal {
+	if divisor.IsZero() {
+		panic(ErrDivisionByZero)
+	}
+	precision := maxPrecision(d.precision, divisor.precision)
+	// d / divisor = (d.ToInt() * 10^(divisor.precision - d.precision)) / divisor.ToInt(), so the
+	// dividend is scaled up by another 10^precision to keep that many digits in the quotient
+	exp := precision + divisor.precision - d.precision
+	a, okA := d.toInt()
+	b, okB := divisor.toInt()
+	scale, okP := pow10(exp)
+	if quotient, ok := mulDivRound(a, scale, b, mode); okA && okB && okP && ok {
+		return *NewDecimal(quotient, precision)
+	}
+	// The quotient doesn't fit in an int64, so compute it with arbitrary precision
+	dividend := d.bigInt()
+	dividend.Mul(dividend, bigPow10(exp))
+	return *newDecimalFromBigInt(bigDivRound(dividend, divisor.bigInt(), mode), precision)
+}
+
+// QuoRem - Divides a decimal by another decimal and returns the integer quotient and the remainder,
+// such that d = quotient * divisor + remainder. The quotient is truncated towards zero, so the
+// remainder has the sign of d, as with the / and % operators on Go integers. For example, 7.5
+// divided by 2 gives 3 and 1.5, and -7.5 divided by 2 gives -3 and -1.5. The quotient has precision
+// 0 and the remainder the precision of the decimal with the largest precision. QuoRem panics if the
+// divisor is zero.
+func (d Decimal) QuoRem(divisor Decimal) (Decimal, Decimal) {
+	return d.QuoRemWithRounding(divisor, RoundDown)
+}
+
+// QuoRemWithRounding - Divides a decimal by another decimal like QuoRem, rounding the quotient to
+// an integer using the rounding mode. RoundDown truncates the quotient like QuoRem, RoundFloor floors
+// it so that the remainder has the sign of the divisor and RoundHalfEven rounds it to the nearest
+// integer so that the remainder is at most half of the divisor.
+func (d Decimal) QuoRemWithRounding(divisor Decimal, mode RoundingMode) (Decimal, Decimal) {
+	if divisor.IsZero() {
+		panic(ErrDivisionByZero)
+	}
+	// Both decimals are compared at the same precision, so the quotient of their scaled integers
+	// is the quotient of the decimals
+	precision := maxPrecision(d.precision, divisor.precision)
+	a, okA := d.toPrecision(precision)
+	b, okB := divisor.toPrecision(precision)
+	quotient, okQ := mulDivRound(a, 1, b, mode)
+	product, okP := mulInt64(quotient, b)
+	if remainder, ok := subInt64(a, product); okA && okB && okQ && okP && ok {
+		return *NewDecimal(quotient, 0), *NewDecimal(remainder, precision)
+	}
+	// The quotient or the remainder doesn't fit in an int64, so compute them with arbitrary precision
+	dividend, bigDivisor := d.bigIntAt(precision), divisor.bigIntAt(precision)
+	bigQuotient := bigDivRound(dividend, bigDivisor, mode)
+	remainder := new(big.Int).Mul(bigQuotient, bigDivisor)
+	remainder.Sub(dividend, remainder)
+	return *newDecimalFromBigInt(bigQuotient, 0), *newDecimalFromBigInt(remainder, precision)
+}
+
+// DivInt - Divides a decimal by another decimal and returns the integer quotient truncated towards
+// zero, as returned by QuoRem. For example, 7.5 divided by 2 gives 3 and -7.5 divided by 2 gives -3.
+// DivInt panics if the divisor is zero.
+func (d Decimal) DivInt(divisor Decimal) Decimal {
+	quotient, _ := d.QuoRem(divisor)
+	return quotient
+}
+
+// Mod - Returns the remainder of the division of a decimal by another decimal using floored
+// division, so the remainder has the sign of the divisor. For example, 7.5 mod 2 is 1.5, -7.5 mod 2
+// is 0.5 and 7.5 mod -2 is -0.5. Use QuoRem for the remainder of truncated division, which has the
+// sign of the decimal. Mod panics if the divisor is zero.
+func (d Decimal) Mod(divisor Decimal) Decimal {
+	_, remainder := d.QuoRemWithRounding(divisor, RoundFloor)
+	return remainder
 }
 
 // AddInt - Adds an integer to decimal
@@ -200,71 +377,322 @@ func (d Decimal) MultiplyByInt(intToMultiply int64) Decimal {
 	return d.Multiply(*intToDec)
 }
 
-// DivideByInt - Divides a decimal by an integer
+// DivideByInt - Divides a decimal by an integer. DivideByInt panics if the integer is zero
 func (d Decimal) DivideByInt(inToDivide int64) Decimal {
 	intToDec := NewDecimal(inToDivide, 0)
 	return d.Divide(*intToDec)
 }
 
-// AddFloat - Adds a float to a decimal
+// AddFloat - Adds a float to a decimal. The float is rounded to the precision of the decimal like in
+// NewDecimalFromFloat, so AddFloat panics if it is NaN or infinite
 func (d Decimal) AddFloat(floatToAdd float64) Decimal {
 	floatToDec := NewDecimalFromFloat(floatToAdd, d.precision)
 	return d.Add(*floatToDec)
 }
 
-// SubtractFloat - Subtracts a float from decimal
+// SubtractFloat - Subtracts a float from decimal. The float is rounded to the precision of the decimal
+// like in NewDecimalFromFloat, so SubtractFloat panics if it is NaN or infinite
 func (d Decimal) SubtractFloat(floatToSubtract float64) Decimal {
 	floatToDec := NewDecimalFromFloat(floatToSubtract, d.precision)
 	return d.Subtract(*floatToDec)
 }
 
-// MultiplyFloat - Multiplies decimal with float
+// MultiplyFloat - Multiplies decimal with float. The float is taken as the shortest decimal that
+// converts back to it, like in NewDecimalFromFloat, and the exact product is rounded half away from
+// zero to the precision of the decimal, so multiplying by 1 gives the same decimal however large it is
 func (d Decimal) MultiplyFloat(floatToMultiply float64) Decimal {
-	decimalToFloat := d.ToFloat()
-	product := decimalToFloat * floatToMultiply
-	return *NewDecimalFromFloat(product, d.precision)
+	numerator, precision := floatFraction(floatToMultiply)
+	// d * float = d * numerator / 10^precision, where only the division rounds
+	product := d.MultiplyWithRounding(numerator, RoundHalfUp)
+	return product.DivideWithRounding(*NewDecimalFromBigInt(bigPow10(precision), 0), RoundHalfUp)
 }
 
-// DivideByFloat - Divides decimal by float
+// DivideByFloat - Divides decimal by float. The float is taken as the shortest decimal that converts
+// back to it, like in NewDecimalFromFloat, and the exact quotient is rounded half away from zero to
+// the precision of the decimal. DivideByFloat panics if the float is zero
 func (d Decimal) DivideByFloat(floatToDivideBy float64) Decimal {
-	decimalToFloat := d.ToFloat()
-	quotient := decimalToFloat / floatToDivideBy
-	return *NewDecimalFromFloat(quotient, d.precision)
+	numerator, precision := floatFraction(floatToDivideBy)
+	// d / float = d * 10^precision / numerator, where only the division rounds
+	dividend := d.MultiplyWithRounding(*NewDecimalFromBigInt(bigPow10(precision), 0), RoundHalfUp)
+	return dividend.DivideWithRounding(numerator, RoundHalfUp)
+}
+
+// floatFraction - Returns the shortest decimal that converts back to the float as an integer
+// numerator with precision 0 and the precision of that decimal, so the float is numerator /
+// 10^precision. It panics with an error matching ErrOutOfRange for NaN and infinities
+func floatFraction(amount float64) (Decimal, uint) {
+	n, err := parseFloat(amount)
+	if err != nil {
+		panic(err)
+	}
+	precision := uint(0)
+	if n.scale > 0 {
+		precision = uint(n.scale)
+	}
+	d, err := n.toDecimal(precision, RoundHalfUp)
+	if err != nil {
+		panic(err)
+	}
+	return *NewDecimalFromBigInt(d.bigInt(), 0), precision
 }
 
 // Split - Will split a decimal to [toParts] parts. If the decimal can't be split
 // in equal parts, i.e. we have a remainder, the first part will be equal to the split
-// amount + remainder.
+// amount + remainder. All parts have the sign of the decimal.
 func (d Decimal) Split(toParts uint) []Decimal {
-	decimalToFloat := d.ToFloat()
 	parts := make([]Decimal, toParts)
-	quotient := decimalToFloat / float64(toParts)
-	quotient *= math.Pow10(int(d.precision))
-	quotient = math.Trunc(quotient)
-	quotient *= math.Pow10(int(d.precision) * -1)
-	var sum float64
-	for i := uint(0); i < toParts; i++ {
-		parts[i] = *NewDecimalFromFloat(quotient, d.precision)
-		sum += quotient
-	}
-	remainder := decimalToFloat - sum
-	parts[0] = parts[0].AddFloat(remainder)
+	if toParts == 0 {
+		return parts
+	}
+	// Split the magnitude, so the remainder has the sign of the decimal too
+	quotient, remainder := new(big.Int).QuoRem(d.magnitude(), new(big.Int).SetUint64(uint64(toParts)), new(big.Int))
+	for i := range parts {
+		parts[i] = d
+		parts[i].setMagnitude(quotient)
+	}
+	parts[0].setMagnitude(remainder.Add(remainder, quotient))
+	// Parts that are zero are not negative
+	for i := range parts {
+		parts[i].negative = d.negative && !parts[i].IsZero()
+	}
 	return parts
 }
 
-// MarshalJSON -
-func (d *Decimal) MarshalJSON() ([]byte, error) {
-	return json.Marshal(d.ToFloat())
+// MarshalJSONAsString - Determines whether MarshalJSON encodes decimals as JSON strings, like
+// "10.50", instead of JSON numbers. Either way the digits are exact, but strings also survive JSON
+// decoders that read numbers as float64. Use StringDecimal to encode some decimals as strings only.
+var MarshalJSONAsString = false
+
+// MarshalJSON - Returns the decimal as a JSON number with the exact digits of ToString, so 10.50
+// with precision 2 is encoded as 10.50, or as a JSON string if MarshalJSONAsString is set. It has a
+// value receiver, so that decimals are marshalled whether they are struct fields or pointers
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if MarshalJSONAsString {
+		return d.marshalJSONString(), nil
+	}
+	return []byte(d.ToString()), nil
 }
 
-// UnmarshalJSON -
+// UnmarshalJSON - Sets the decimal to a JSON number or a JSON string containing a number, like 10.50
+// or "10.50", without converting it to a float. The decimal always gets the exact digits and the
+// precision of the number, whatever it held before, so unmarshalling 35.230 gives 35.230 and decoding
+// into a reused variable or an existing slice element loses nothing. Use the decimal struct tags of
+// UnmarshalJSON to give decoded fields a fixed scale. JSON null leaves the decimal unchanged
 func (d *Decimal) UnmarshalJSON(data []byte) error {
-	var floatVal float64
-	if err := json.Unmarshal(data, &floatVal); err != nil {
-		return fmt.Errorf("unmarshal decimal: %v", err.Error())
+	if string(data) == "null" {
+		return nil
 	}
-	dc := NewDecimalFromFloat(floatVal, d.precision)
-	d.whole = dc.whole
-	d.fraction = dc.fraction
+	value := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return fmt.Errorf("unmarshal decimal: %w", err)
+		}
+	}
+	dc, err := NewDecimalFromString(value)
+	if err != nil {
+		return fmt.Errorf("unmarshal decimal: %w", err)
+	}
+	*d = *dc
 	return nil
 }
+
+// MarshalText - Returns the decimal as text with the exact digits of ToString, implementing
+// encoding.TextMarshaler, so decimals can be map keys in JSON and attributes in XML
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.ToString()), nil
+}
+
+// UnmarshalText - Sets the decimal to text in the format of NewDecimalFromString, implementing
+// encoding.TextUnmarshaler. Like UnmarshalJSON, the decimal always gets the exact digits and the
+// precision of the text, so config loaders and map keys that reuse a decimal lose nothing
+func (d *Decimal) UnmarshalText(text []byte) error {
+	dc, err := NewDecimalFromString(string(text))
+	if err != nil {
+		return fmt.Errorf("unmarshal decimal: %w", err)
+	}
+	*d = *dc
+	return nil
+}
+
+// marshalJSONString - Returns the decimal as a JSON string
+func (d Decimal) marshalJSONString() []byte {
+	return []byte(`"` + d.ToString() + `"`)
+}
+
+// NewDecimalFromJSONNumber - Creates a new decimal from a json.Number, as returned by a json.Decoder
+// with UseNumber, with the precision of the number like NewDecimalFromString
+func NewDecimalFromJSONNumber(number json.Number) (*Decimal, error) {
+	return NewDecimalFromString(number.String())
+}
+
+// StringDecimal - A decimal that is always encoded as a JSON string, like "10.50", whatever the value
+// of MarshalJSONAsString. It is decoded like a Decimal
+type StringDecimal struct {
+	Decimal
+}
+
+// MarshalJSON - Returns the decimal as a JSON string
+func (d StringDecimal) MarshalJSON() ([]byte, error) {
+	return d.marshalJSONString(), nil
+}
+
+// toPrecision - Returns the integer representation of decimal at a precision that is at least
+// the precision of the decimal, and whether it fits in an int64
+func (d Decimal) toPrecision(precision uint) (int64, bool) {
+	value, ok := d.toInt()
+	value, scaleOK := rescale(value, d.precision, precision, RoundHalfUp)
+	return value, ok && scaleOK
+}
+
+// parts - Returns the magnitudes of the whole and the fractional part of the decimal. Parts
+// that don't fit in an uint64 are truncated
+func (d Decimal) parts() (uint64, uint64) {
+	if d.bigCoefficient != nil {
+		whole, fraction := new(big.Int).QuoRem(d.bigCoefficient, bigPow10(d.precision), new(big.Int))
+		return whole.Uint64(), fraction.Uint64()
+	}
+	// 10^20 and larger powers don't fit in an uint64 and are larger than any coefficient
+	if d.precision >= 20 {
+		return 0, d.coefficient
+	}
+	p := uint64(1)
+	for i := uint(0); i < d.precision; i++ {
+		p *= 10
+	}
+	return d.coefficient / p, d.coefficient % p
+}
+
+// appendMagnitude - Appends the magnitude of the decimal to dst with the decimal point and grouping
+// of the formatter. The fraction has as many digits as the precision and the whole part at least one
+func (d Decimal) appendMagnitude(dst []byte, f Formatter) []byte {
+	var buf [20]byte
+	var digits []byte
+	if d.bigCoefficient != nil {
+		digits = d.bigCoefficient.Append(buf[:0], 10)
+	} else {
+		digits = strconv.AppendUint(buf[:0], d.coefficient, 10)
+	}
+	wholeLen := len(digits) - int(d.precision)
+	if wholeLen > 0 {
+		dst = f.appendGrouped(dst, digits[:wholeLen])
+	} else {
+		dst = append(dst, '0')
+	}
+	if d.precision > 0 {
+		dst = append(dst, f.decimalPoint())
+		// Pad the fraction with zeros to the left when the coefficient has fewer digits
+		for ; wholeLen < 0; wholeLen++ {
+			dst = append(dst, '0')
+		}
+		dst = append(dst, digits[wholeLen:]...)
+	}
+	return dst
+}
+
+// maxSupportedPrecision - The largest precision for which 10^precision fits in an int64
+const maxSupportedPrecision = 18
+
+// pow10 - Returns 10 to the power of n as an integer and whether it fits in an int64
+func pow10(n uint) (int64, bool) {
+	if n > maxSupportedPrecision {
+		return 0, false
+	}
+	p := int64(1)
+	for i := uint(0); i < n; i++ {
+		p *= 10
+	}
+	return p, true
+}
+
+// maxPrecision - Returns the largest of two precisions
+func maxPrecision(a, b uint) uint {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// rescale - Converts a scaled integer from one precision to another and returns whether
+// the result fits in an int64. When the precision is reduced the value is rounded using
+// the rounding mode
+func rescale(value int64, from, to uint, mode RoundingMode) (int64, bool) {
+	if to >= from {
+		p, ok := pow10(to - from)
+		product, mulOK := mulInt64(value, p)
+		return product, ok && mulOK
+	}
+	p, ok := pow10(from - to)
+	quotient, divOK := mulDivRound(value, 1, p, mode)
+	return quotient, ok && divOK
+}
+
+// mulDivRound - Returns a * b / c rounded using the rounding mode and whether it fits in an
+// int64. The product is computed with 128 bits, so it may exceed an int64 as long as the
+// quotient does not. A zero c is reported as not fitting, callers check for division by zero
+func mulDivRound(a, b, c int64, mode RoundingMode) (int64, bool) {
+	if c == 0 {
+		return 0, false
+	}
+	negative := (a < 0) != (b < 0) != (c < 0)
+	hi, lo := bits.Mul64(absUint64(a), absUint64(b))
+	divisor := absUint64(c)
+	if hi >= divisor {
+		return 0, false
+	}
+	quotient, remainder := bits.Div64(hi, lo, divisor)
+	if remainder != 0 {
+		// Compare the remainder to half of the divisor as remainder against divisor - remainder
+		half := 0
+		if remainder < divisor-remainder {
+			half = -1
+		} else if remainder > divisor-remainder {
+			half = 1
+		}
+		if mode.roundsAway(negative, quotient%10, half) {
+			quotient++
+			if quotient == 0 {
+				return 0, false
+			}
+		}
+	}
+	return toInt64(quotient, negative)
+}
+
+// toInt64 - Returns the integer with the given magnitude and sign and whether it fits in an int64
+func toInt64(magnitude uint64, negative bool) (int64, bool) {
+	if negative {
+		return -int64(magnitude), magnitude <= 1<<63
+	}
+	return int64(magnitude), magnitude < 1<<63
+}
+
+// addInt64 - Returns a + b and whether the sum fits in an int64
+func addInt64(a, b int64) (int64, bool) {
+	sum := a + b
+	// The sum overflows when both operands have the same sign and the sum has the other sign
+	return sum, (a < 0) != (b < 0) || (sum < 0) == (a < 0)
+}
+
+// subInt64 - Returns a - b and whether the difference fits in an int64
+func subInt64(a, b int64) (int64, bool) {
+	difference := a - b
+	// The difference overflows when the operands have different signs and the difference
+	// doesn't have the sign of a
+	return difference, (a < 0) == (b < 0) || (difference < 0) == (a < 0)
+}
+
+// mulInt64 - Returns a * b and whether the product fits in an int64
+func mulInt64(a, b int64) (int64, bool) {
+	hi, lo := bits.Mul64(absUint64(a), absUint64(b))
+	product, ok := toInt64(lo, (a < 0) != (b < 0))
+	return product, ok && hi == 0
+}
+
+// absUint64 - Returns the absolute value of an integer as an unsigned integer, so that the
+// absolute value of the smallest int64 doesn't overflow
+func absUint64(n int64) uint64 {
+	if n < 0 {
+		return uint64(-n)
+	}
+	return uint64(n)
+}
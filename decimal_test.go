@@ -1,6 +1,10 @@
 package decimal_test
 
 import (
+	"encoding/json"
+	"encoding/xml"
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/petrossordinas/decimal"
@@ -21,6 +25,9 @@ func TestNewDecimal(t *testing.T) {
 		{18, 2, 0, 18},
 		{271828, 5, 2, 71828},
 		{0, 2, 0, 0},
+		{-12357, 3, -12, -357},
+		{-50, 2, 0, -50},
+		{-5, 0, -5, 0},
 	}
 	assert := assert.New(t)
 	for testNo, tc := range tests {
@@ -54,6 +61,112 @@ func TestNewDecimalFromFloat(t *testing.T) {
 	}
 }
 
+func TestNewDecimalFromFloatWithRounding(t *testing.T) {
+	tests := []struct {
+		floatVal  float64
+		precision uint
+		mode      decimal.RoundingMode
+		intVal    int64
+	}{
+		{2.675, 2, decimal.RoundHalfUp, 268},
+		{2.665, 2, decimal.RoundHalfEven, 266},
+		{2.675, 2, decimal.RoundHalfEven, 268},
+		{2.675, 2, decimal.RoundHalfDown, 267},
+		{0.1, 2, decimal.RoundCeiling, 10},
+		{2.671, 2, decimal.RoundCeiling, 268},
+		{-2.671, 2, decimal.RoundCeiling, -267},
+		{-2.671, 2, decimal.RoundFloor, -268},
+		{2.679, 2, decimal.RoundDown, 267},
+		{2.671, 2, decimal.RoundUp, 268},
+		{2.651, 2, decimal.Round05Up, 266},
+		{0.999, 2, decimal.RoundHalfUp, 100},
+		{12.5, 0, decimal.RoundHalfEven, 12},
+		{1.005, 2, decimal.RoundHalfUp, 101},
+		{1.005, 2, decimal.RoundHalfEven, 100},
+		{-1.005, 2, decimal.RoundHalfUp, -101},
+		{0.285, 2, decimal.RoundHalfUp, 29},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d := decimal.NewDecimalFromFloatWithRounding(tc.floatVal, tc.precision, tc.mode)
+		assert.Equal(tc.intVal, d.ToInt(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestNewDecimalFromFloatShortestDigits(t *testing.T) {
+	tests := []struct {
+		floatVal  float64
+		precision uint
+		result    string
+	}{
+		{1e20, 2, "100000000000000000000.00"},
+		{-9.2233720368547758e18, 0, "-9223372036854776000"},
+		{0.1, 20, "0.10000000000000000000"},
+		{1.7976931348623157e308, 0, "17976931348623157" + strings.Repeat("0", 292)},
+		{5e-324, 2, "0.00"},
+		{123.456, 0, "123"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d := decimal.NewDecimalFromFloat(tc.floatVal, tc.precision)
+		assert.Equal(tc.result, d.ToString(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestNewDecimalFromFloatChecked(t *testing.T) {
+	tests := []struct {
+		floatVal  float64
+		precision uint
+	}{
+		{math.NaN(), 2},
+		{math.Inf(1), 2},
+		{math.Inf(-1), 0},
+		{1.5, 10001},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, err := decimal.NewDecimalFromFloatChecked(tc.floatVal, tc.precision, decimal.RoundHalfUp)
+		assert.Nil(d, "Test No: %d - Should be nil", testNo+1)
+		assert.ErrorIs(err, decimal.ErrOutOfRange, "Test No: %d - Was expecting error", testNo+1)
+		// The unchecked conversions and float arithmetic panic instead of returning zero
+		assert.Panics(func() { decimal.NewDecimalFromFloat(tc.floatVal, tc.precision) }, "Test No: %d - Should panic", testNo+1)
+		assert.Panics(func() { decimal.NewDecimal(10, tc.precision).AddFloat(tc.floatVal) }, "Test No: %d - Should panic", testNo+1)
+	}
+	d, err := decimal.NewDecimalFromFloatChecked(2.675, 2, decimal.RoundDown)
+	assert.Nil(err)
+	assert.Equal("2.67", d.ToString())
+}
+
+func TestNewDecimalFromFloatExact(t *testing.T) {
+	tests := []struct {
+		floatVal float64
+		result   string
+	}{
+		{0.5, "0.5"},
+		{-2, "-2"},
+		{0, "0"},
+		{0.1, "0.1000000000000000055511151231257827021181583404541015625"},
+		{1.005, "1.00499999999999989341858963598497211933135986328125"},
+		{1e23, "99999999999999991611392"},
+		{-0.375, "-0.375"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, err := decimal.NewDecimalFromFloatExact(tc.floatVal)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(tc.result, d.ToString(), "Test No: %d - Should be equal", testNo+1)
+		// The exact decimal converts back to the same float
+		assert.Equal(tc.floatVal, d.ToFloat(), "Test No: %d - Should be equal", testNo+1)
+	}
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		d, err := decimal.NewDecimalFromFloatExact(f)
+		assert.Nil(d)
+		assert.ErrorIs(err, decimal.ErrOutOfRange)
+	}
+	d, _ := decimal.NewDecimalFromFloatExact(5e-324)
+	assert.Equal(uint(1074), d.GetPrecision())
+}
+
 func TestDecimalToInt(t *testing.T) {
 	tests := []struct {
 		floatVal  float64
@@ -110,6 +223,11 @@ func TestDecimalToString(t *testing.T) {
 		{271828, 5, "2.71828"},
 		{0, 2, "0.00"},
 		{0, 1, "0.0"},
+		{-50, 2, "-0.50"},
+		{-5, 3, "-0.005"},
+		{-12357, 3, "-12.357"},
+		{-7, 0, "-7"},
+		{922337203685477580, 3, "922337203685477.580"},
 	}
 	assert := assert.New(t)
 	for testNo, tc := range tests {
@@ -128,10 +246,17 @@ func TestDecimalToStringFormatted(t *testing.T) {
 		{12357, 3, "12,357"},
 		{314159265358979, 14, "3,14159265358979"},
 		{38948737383, 4, "3.894.873,7383"},
+		{2334599, 2, "23.345,99"},
+		{12345678, 0, "12.345.678"},
+		{100000, 1, "10.000,0"},
 		{18, 2, "0,18"},
 		{271828, 5, "2,71828"},
 		{0, 2, "0,00"},
 		{0, 1, "0,0"},
+		{-50, 2, "-0,50"},
+		{-12357, 3, "-12,357"},
+		{-38948737383, 4, "-3.894.873,7383"},
+		{-100000, 0, "-100.000"},
 	}
 	assert := assert.New(t)
 	for testNo, tc := range tests {
@@ -160,9 +285,8 @@ func TestDecimalToStringFormattedWithChangingSeparators(t *testing.T) {
 	assert := assert.New(t)
 	for testNo, tc := range tests {
 		d := decimal.NewDecimal(tc.intVal, tc.precision)
-		d.SetDecimalPoint(tc.decimalPoint)
-		d.SetThousandSeparator(tc.thousandSeparator)
-		assert.EqualValues(tc.strVal, d.ToStringFormatted(), "Test No: %d - Should be equal", testNo+1)
+		f := decimal.Formatter{DecimalPoint: tc.decimalPoint, ThousandSeparator: tc.thousandSeparator}
+		assert.EqualValues(tc.strVal, f.Format(*d), "Test No: %d - Should be equal", testNo+1)
 	}
 }
 
@@ -195,6 +319,8 @@ func TestIsNegative(t *testing.T) {
 		{0, 2, false},
 		{-4990, 1, true},
 		{0, 0, false},
+		{-50, 2, true},
+		{-1, 18, true},
 	}
 	assert := assert.New(t)
 	for testNo, tc := range tests {
@@ -203,6 +329,79 @@ func TestIsNegative(t *testing.T) {
 	}
 }
 
+func TestAbsNeg(t *testing.T) {
+	tests := []struct {
+		strVal string
+		abs    string
+		neg    string
+	}{
+		{"123.45", "123.45", "-123.45"},
+		{"-123.45", "123.45", "123.45"},
+		{"-0.50", "0.50", "0.50"},
+		{"0.00", "0.00", "0.00"},
+		{"-9223372036854775808", "9223372036854775808", "9223372036854775808"},
+		{"123456789012345678901234567890", "123456789012345678901234567890", "-123456789012345678901234567890"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, _ := decimal.NewDecimalFromString(tc.strVal)
+		assert.Equal(tc.abs, d.Abs().ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.neg, d.Neg().ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.True(d.Neg().Neg().Equal(*d), "Test No: %d - Should be equal", testNo+1)
+	}
+	assert.False(decimal.NewDecimal(0, 2).Neg().IsNegative())
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		strVal    string
+		result    string
+		precision uint
+	}{
+		{"1.2500", "1.25", 2},
+		{"-1.2500", "-1.25", 2},
+		{"0.000", "0", 0},
+		{"100.00", "100", 0},
+		{"100", "100", 0},
+		{"0.001", "0.001", 3},
+		{"1234567890123456789012345678.9000000000", "1234567890123456789012345678.9", 1},
+		{"12345678901234567890123456789.0000000000", "12345678901234567890123456789", 0},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, _ := decimal.NewDecimalFromString(tc.strVal)
+		normalized := d.Normalize()
+		assert.Equal(tc.result, normalized.ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.precision, normalized.GetPrecision(), "Test No: %d - Should be equal", testNo+1)
+	}
+	// Decimals with the same value have the same normalized form
+	assert.Equal(decimal.NewDecimal(150, 2).Normalize(), decimal.NewDecimal(15, 1).Normalize())
+}
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		strVal1 string
+		strVal2 string
+		key     string
+	}{
+		{"1.2500", "1.25", "1.25"},
+		{"-0.000", "0", "0"},
+		{"100.00", "100", "100"},
+		{"123456789012345678901234567890", "123456789012345678901234567890.00", "123456789012345678901234567890"},
+		{"-1234567890123456789012345678.90", "-1234567890123456789012345678.9000000000", "-1234567890123456789012345678.9"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1, _ := decimal.NewDecimalFromString(tc.strVal1)
+		d2, _ := decimal.NewDecimalFromString(tc.strVal2)
+		assert.Equal(tc.key, d1.Key(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.key, d2.Key(), "Test No: %d - Should be equal", testNo+1)
+		// A map keyed by one decimal finds the other
+		seen := map[string]bool{d1.Key(): true}
+		assert.True(seen[d2.Key()], "Test No: %d - Should be found", testNo+1)
+	}
+}
+
 func TestAdd(t *testing.T) {
 	tests := []struct {
 		decimal1   float64
@@ -241,6 +440,7 @@ func TestSubtract(t *testing.T) {
 		{10.75, 2, -15.75, 2, "26.50"},
 		{-10.7, 2, -4.3, 5, "-6.40000"},
 		{0.0, 3, 17.5, 1, "-17.500"},
+		{0.25, 2, 0.75, 2, "-0.50"},
 	}
 	assert := assert.New(t)
 	for testNo, tc := range tests {
@@ -302,6 +502,186 @@ func TestDivide(t *testing.T) {
 	}
 }
 
+func TestMultiplyWithRounding(t *testing.T) {
+	tests := []struct {
+		intVal1    int64
+		precision1 uint
+		intVal2    int64
+		precision2 uint
+		mode       decimal.RoundingMode
+		result     string
+	}{
+		{25, 1, 5, 1, decimal.RoundHalfUp, "1.3"},
+		{25, 1, 5, 1, decimal.RoundHalfEven, "1.2"},
+		{25, 1, 5, 1, decimal.RoundHalfDown, "1.2"},
+		{-25, 1, 5, 1, decimal.RoundFloor, "-1.3"},
+		{2456, 2, 544, 2, decimal.RoundDown, "133.60"},
+		{2456, 2, 544, 2, decimal.RoundCeiling, "133.61"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1 := decimal.NewDecimal(tc.intVal1, tc.precision1)
+		d2 := decimal.NewDecimal(tc.intVal2, tc.precision2)
+		product := d1.MultiplyWithRounding(*d2, tc.mode)
+		assert.Equal(tc.result, product.ToString(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestDivideWithRounding(t *testing.T) {
+	tests := []struct {
+		intVal1    int64
+		precision1 uint
+		intVal2    int64
+		precision2 uint
+		mode       decimal.RoundingMode
+		result     string
+	}{
+		{1000, 2, 300, 2, decimal.RoundHalfUp, "3.33"},
+		{1000, 2, 300, 2, decimal.RoundCeiling, "3.34"},
+		{-1000, 2, 300, 2, decimal.RoundCeiling, "-3.33"},
+		{-1000, 2, 300, 2, decimal.RoundFloor, "-3.34"},
+		{200, 2, 300, 2, decimal.RoundDown, "0.66"},
+		{1, 0, 8, 0, decimal.RoundUp, "1"},
+		{25, 0, 10, 0, decimal.RoundHalfEven, "2"},
+		{35, 0, 10, 0, decimal.RoundHalfEven, "4"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1 := decimal.NewDecimal(tc.intVal1, tc.precision1)
+		d2 := decimal.NewDecimal(tc.intVal2, tc.precision2)
+		quotient := d1.DivideWithRounding(*d2, tc.mode)
+		assert.Equal(tc.result, quotient.ToString(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestQuoRem(t *testing.T) {
+	tests := []struct {
+		dividend  string
+		divisor   string
+		quotient  string
+		remainder string
+	}{
+		{"7.5", "2", "3", "1.5"},
+		{"-7.5", "2", "-3", "-1.5"},
+		{"7.5", "-2", "-3", "1.5"},
+		{"-7.5", "-2", "3", "-1.5"},
+		{"100.00", "3", "33", "1.00"},
+		{"10", "0.3", "33", "0.1"},
+		{"1", "0.003", "333", "0.001"},
+		{"0.5", "2", "0", "0.5"},
+		{"6", "2", "3", "0"},
+		{"9223372036854775807", "0.1", "92233720368547758070", "0.0"},
+		{"123456789012345678901234567890.5", "7", "17636684144620811271604938270", "0.5"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1, _ := decimal.NewDecimalFromString(tc.dividend)
+		d2, _ := decimal.NewDecimalFromString(tc.divisor)
+		quotient, remainder := d1.QuoRem(*d2)
+		assert.Equal(tc.quotient, quotient.ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.remainder, remainder.ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.quotient, d1.DivInt(*d2).ToString(), "Test No: %d - Should be equal", testNo+1)
+		// The quotient and the remainder add up to the dividend
+		assert.True(quotient.Multiply(*d2).Add(remainder).Equal(*d1), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestQuoRemWithRounding(t *testing.T) {
+	tests := []struct {
+		dividend  string
+		divisor   string
+		mode      decimal.RoundingMode
+		quotient  string
+		remainder string
+	}{
+		{"-7.5", "2", decimal.RoundDown, "-3", "-1.5"},
+		{"-7.5", "2", decimal.RoundFloor, "-4", "0.5"},
+		{"7.5", "-2", decimal.RoundFloor, "-4", "-0.5"},
+		{"7.5", "2", decimal.RoundCeiling, "4", "-0.5"},
+		{"7", "2", decimal.RoundHalfEven, "4", "-1"},
+		{"5", "2", decimal.RoundHalfEven, "2", "1"},
+		{"7.4", "2", decimal.RoundHalfEven, "4", "-0.6"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1, _ := decimal.NewDecimalFromString(tc.dividend)
+		d2, _ := decimal.NewDecimalFromString(tc.divisor)
+		quotient, remainder := d1.QuoRemWithRounding(*d2, tc.mode)
+		assert.Equal(tc.quotient, quotient.ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.remainder, remainder.ToString(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestMod(t *testing.T) {
+	tests := []struct {
+		dividend string
+		divisor  string
+		result   string
+	}{
+		{"7.5", "2", "1.5"},
+		{"-7.5", "2", "0.5"},
+		{"7.5", "-2", "-0.5"},
+		{"-7.5", "-2", "-1.5"},
+		{"-1", "7", "6"},
+		{"14", "7", "0"},
+		{"-14", "7", "0"},
+		{"10.25", "0.1", "0.05"},
+		{"-100000000000000000000", "3", "2"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1, _ := decimal.NewDecimalFromString(tc.dividend)
+		d2, _ := decimal.NewDecimalFromString(tc.divisor)
+		assert.Equal(tc.result, d1.Mod(*d2).ToString(), "Test No: %d - Should be equal", testNo+1)
+	}
+	assert.PanicsWithValue(decimal.ErrDivisionByZero, func() { decimal.NewDecimal(1, 0).Mod(*decimal.NewDecimal(0, 2)) })
+}
+
+func TestArithmeticIsExact(t *testing.T) {
+	tests := []struct {
+		op         string
+		intVal1    int64
+		precision1 uint
+		intVal2    int64
+		precision2 uint
+		result     int64
+		precision  uint
+	}{
+		{"add", 1, 1, 2, 1, 3, 1},
+		{"add", 900719925474099312, 2, 1, 2, 900719925474099313, 2},
+		{"subtract", 3, 1, 1, 1, 2, 1},
+		{"subtract", 100000000000000001, 3, 1, 0, 99999999999999001, 3},
+		{"multiply", 1005, 3, 1000, 3, 1005, 3},
+		{"multiply", 45, 2, 1, 1, 5, 2},
+		{"multiply", -45, 2, 1, 1, -5, 2},
+		{"multiply", 4400000000000001, 4, 10, 1, 4400000000000001, 4},
+		{"divide", 100, 2, 300, 2, 33, 2},
+		{"divide", 200, 2, 300, 2, 67, 2},
+		{"divide", -200, 2, 300, 2, -67, 2},
+		{"divide", 1, 0, 8, 0, 0, 0},
+		{"divide", 5, 0, 10, 0, 1, 0},
+		{"divide", 123456789012345678, 6, 2, 0, 61728394506172839, 6},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d1 := decimal.NewDecimal(tc.intVal1, tc.precision1)
+		d2 := decimal.NewDecimal(tc.intVal2, tc.precision2)
+		var result decimal.Decimal
+		switch tc.op {
+		case "add":
+			result = d1.Add(*d2)
+		case "subtract":
+			result = d1.Subtract(*d2)
+		case "multiply":
+			result = d1.Multiply(*d2)
+		case "divide":
+			result = d1.Divide(*d2)
+		}
+		assert.Equal(tc.result, result.ToInt(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(*decimal.NewDecimal(tc.result, tc.precision), result, "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
 func TestAddInt(t *testing.T) {
 	tests := []struct {
 		decimal   float64
@@ -483,6 +863,38 @@ func TestDivideByFloat(t *testing.T) {
 	}
 }
 
+func TestFloatArithmeticIsExact(t *testing.T) {
+	tests := []struct {
+		strVal string
+		float  float64
+		op     string
+		result string
+	}{
+		{"12345678901234567.89", 1, "multiply", "12345678901234567.89"},
+		{"123456789012345678901234.5", 1, "divide", "123456789012345678901234.5"},
+		{"123456789012345678901234.5", 0.1, "multiply", "12345678901234567890123.5"},
+		{"123456789012345678901234.5", 0.1, "divide", "1234567890123456789012345.0"},
+		{"1.000000000000000000001", 3, "multiply", "3.000000000000000000003"},
+		{"10.00", 1e21, "multiply", "10000000000000000000000.00"},
+		{"1.00", 3, "divide", "0.33"},
+		{"-1.00", 1.5, "divide", "-0.67"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, _ := decimal.NewDecimalFromString(tc.strVal)
+		var result decimal.Decimal
+		switch tc.op {
+		case "multiply":
+			result = d.MultiplyFloat(tc.float)
+		case "divide":
+			result = d.DivideByFloat(tc.float)
+		}
+		assert.Equal(tc.result, result.ToString(), "Test No: %d - Should be equal", testNo+1)
+	}
+	assert.PanicsWithValue(decimal.ErrDivisionByZero, func() { decimal.NewDecimal(1, 0).DivideByFloat(0) })
+	assert.Panics(func() { decimal.NewDecimal(1, 0).MultiplyFloat(math.NaN()) })
+}
+
 func TestSplit(t *testing.T) {
 	tests := []struct {
 		decimal    float64
@@ -495,6 +907,10 @@ func TestSplit(t *testing.T) {
 		{19.00, 2, 6, []string{"3.20", "3.16", "3.16", "3.16", "3.16", "3.16"}},
 		{11.0, 4, 3, []string{"3.6668", "3.6666", "3.6666"}},
 		{31.0, 3, 7, []string{"4.432", "4.428", "4.428", "4.428", "4.428", "4.428", "4.428"}},
+		{-10.00, 2, 3, []string{"-3.34", "-3.33", "-3.33"}},
+		{-0.10, 2, 3, []string{"-0.04", "-0.03", "-0.03"}},
+		{-0.02, 2, 3, []string{"-0.02", "0.00", "0.00"}},
+		{0.5, 1, 1, []string{"0.5"}},
 	}
 	assert := assert.New(t)
 	for testNo, tc := range tests {
@@ -515,7 +931,7 @@ func TestMarshalJSON(t *testing.T) {
 		result    string
 	}{
 		{35.23, 2, "35.23"},
-		{35.32000, 5, "35.32"},
+		{35.32000, 5, "35.32000"},
 		{148.495049, 6, "148.495049"},
 		{23.459, 2, "23.46"},
 		{12.009, 2, "12.01"},
@@ -537,9 +953,10 @@ func TestUnmarshalJSON(t *testing.T) {
 	}{
 		{"35.23", 2, "35.23"},
 		{"35.32000", 5, "35.32000"},
-		{"148.495049", 2, "148.50"},
-		{"23.459", 2, "23.46"},
-		{"12.009", 2, "12.01"},
+		{"148.495049", 2, "148.495049"},
+		{"23.459", 2, "23.459"},
+		{"12.009", 0, "12.009"},
+		{"12", 2, "12"},
 	}
 	assert := assert.New(t)
 	for testNo, tc := range tests {
@@ -549,6 +966,159 @@ func TestUnmarshalJSON(t *testing.T) {
 		assert.Equal(tc.result, d.ToString(), "Test No: %d - Should be equal", testNo+1)
 	}
 }
+
+func TestUnmarshalJSONReused(t *testing.T) {
+	assert := assert.New(t)
+	// Decoding doesn't depend on what the decimal held before
+	var d decimal.Decimal
+	for _, value := range []string{"10.50", "1.2345", "7", "0.125"} {
+		assert.Nil(json.Unmarshal([]byte(value), &d))
+		assert.Equal(value, d.ToString())
+	}
+	// encoding/json decodes into the existing elements of a slice
+	amounts := []decimal.Decimal{*decimal.NewDecimal(1050, 2), *decimal.NewDecimal(7, 0)}
+	assert.Nil(json.Unmarshal([]byte(`[1.2345, 0.125, 3]`), &amounts))
+	assert.Equal([]string{"1.2345", "0.125", "3"}, []string{amounts[0].ToString(), amounts[1].ToString(), amounts[2].ToString()})
+}
+
+func TestZeroValue(t *testing.T) {
+	assert := assert.New(t)
+	var d decimal.Decimal
+	assert.True(d.IsZero())
+	assert.Equal(*decimal.NewDecimal(0, 0), d)
+	assert.Equal("0", d.ToString())
+	assert.Equal("0", d.ToStringFormatted())
+	assert.Equal("12,50", d.Add(*decimal.NewDecimal(1250, 2)).ToStringFormatted())
+	json, err := d.MarshalJSON()
+	assert.Nil(err)
+	assert.Equal("0", string(json))
+}
+
+func TestUnmarshalJSONZeroValue(t *testing.T) {
+	tests := []struct {
+		json      string
+		result    string
+		precision uint
+	}{
+		{"35.23", "35.23", 2},
+		{"35.230", "35.230", 3},
+		{"-0.5", "-0.5", 1},
+		{"12", "12", 0},
+		{"1e-3", "0.001", 3},
+		{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789", 9},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		var v struct {
+			Amount decimal.Decimal `json:"amount"`
+		}
+		err := json.Unmarshal([]byte(`{"amount": `+tc.json+`}`), &v)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(tc.result, v.Amount.ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.precision, v.Amount.GetPrecision(), "Test No: %d - Should be equal", testNo+1)
+	}
+	// A decimal that is a struct field is marshalled as a number and null leaves it unchanged
+	v := struct {
+		Amount decimal.Decimal `json:"amount"`
+	}{*decimal.NewDecimal(-3523, 2)}
+	data, err := json.Marshal(v)
+	assert.Nil(err)
+	assert.Equal(`{"amount":-35.23}`, string(data))
+	assert.Nil(json.Unmarshal([]byte(`{"amount": null}`), &v))
+	assert.Equal("-35.23", v.Amount.ToString())
+}
+
+func TestJSONIsExact(t *testing.T) {
+	tests := []struct {
+		strVal string
+		json   string
+	}{
+		{"10.50", "10.50"},
+		{"-0.001", "-0.001"},
+		{"0.00", "0.00"},
+		{"9007199254740993", "9007199254740993"},
+		{"123456789012345678901234567890.123456789012345678", "123456789012345678901234567890.123456789012345678"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, _ := decimal.NewDecimalFromString(tc.strVal)
+		data, err := json.Marshal(d)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(tc.json, string(data), "Test No: %d - Should be equal", testNo+1)
+		data, err = json.Marshal(decimal.StringDecimal{Decimal: *d})
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(`"`+tc.json+`"`, string(data), "Test No: %d - Should be equal", testNo+1)
+		// Both forms decode to the same decimal
+		var fromNumber, fromString decimal.Decimal
+		assert.Nil(json.Unmarshal([]byte(tc.json), &fromNumber), "Test No: %d - Was not expecting error", testNo+1)
+		assert.Nil(json.Unmarshal(data, &fromString), "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(*d, fromNumber, "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(*d, fromString, "Test No: %d - Should be equal", testNo+1)
+		var stringDecimal decimal.StringDecimal
+		assert.Nil(json.Unmarshal(data, &stringDecimal), "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(*d, stringDecimal.Decimal, "Test No: %d - Should be equal", testNo+1)
+		number, err := decimal.NewDecimalFromJSONNumber(json.Number(tc.json))
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(*d, *number, "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestMarshalJSONAsString(t *testing.T) {
+	assert := assert.New(t)
+	decimal.MarshalJSONAsString = true
+	defer func() { decimal.MarshalJSONAsString = false }()
+	data, err := json.Marshal(struct {
+		Amount decimal.Decimal `json:"amount"`
+	}{*decimal.NewDecimal(1050, 2)})
+	assert.Nil(err)
+	assert.Equal(`{"amount":"10.50"}`, string(data))
+}
+
+func TestMarshalText(t *testing.T) {
+	tests := []string{"10.50", "-0.001", "0", "123456789012345678901234567890.123456789012345678"}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, _ := decimal.NewDecimalFromString(tc)
+		text, err := d.MarshalText()
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(tc, string(text), "Test No: %d - Should be equal", testNo+1)
+		var parsed decimal.Decimal
+		assert.Nil(parsed.UnmarshalText(text), "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(*d, parsed, "Test No: %d - Should be equal", testNo+1)
+	}
+	// A reused decimal gets the digits of each text
+	var d decimal.Decimal
+	for _, text := range []string{"1.5", "2.25", "7", "0.125"} {
+		assert.Nil(d.UnmarshalText([]byte(text)))
+		assert.Equal(text, d.ToString())
+	}
+	assert.NotNil(d.UnmarshalText([]byte("10,50")))
+	assert.NotNil(d.UnmarshalText(nil))
+}
+
+func TestTextEncodings(t *testing.T) {
+	assert := assert.New(t)
+	// Decimals as JSON map keys
+	rates := map[decimal.Decimal]string{*decimal.NewDecimal(1050, 2): "reduced"}
+	data, err := json.Marshal(rates)
+	assert.Nil(err)
+	assert.Equal(`{"10.50":"reduced"}`, string(data))
+	var decoded map[decimal.Decimal]string
+	assert.Nil(json.Unmarshal(data, &decoded))
+	assert.Equal(rates, decoded)
+
+	// Decimals as XML attributes
+	type item struct {
+		Price decimal.Decimal `xml:"price,attr"`
+	}
+	data, err = xml.Marshal(item{Price: *decimal.NewDecimal(-1999, 2)})
+	assert.Nil(err)
+	assert.Equal(`<item price="-19.99"></item>`, string(data))
+	var i item
+	assert.Nil(xml.Unmarshal(data, &i))
+	assert.Equal("-19.99", i.Price.ToString())
+}
+
 func TestUnmarshalJSONerrors(t *testing.T) {
 	tests := []struct {
 		json      string
@@ -557,6 +1127,10 @@ func TestUnmarshalJSONerrors(t *testing.T) {
 	}{
 		{"35.23x", 2, "35.23"},
 		{"35 32000", 5, "35.32000"},
+		{`"35.23x"`, 2, "35.23"},
+		{`""`, 2, ""},
+		{`"35.23`, 2, "35.23"},
+		{"true", 2, ""},
 	}
 	assert := assert.New(t)
 	for testNo, tc := range tests {
@@ -0,0 +1,271 @@
+package decimal
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// NegativeStyle - Determines how a Formatter marks a negative decimal
+type NegativeStyle int
+
+const (
+	// NegativeLeadingMinus puts a minus sign before the number, as in -1.234,56. This is the
+	// style used when none is set.
+	NegativeLeadingMinus NegativeStyle = iota
+	// NegativeTrailingMinus puts a minus sign after the number, as in 1.234,56-
+	NegativeTrailingMinus
+	// NegativeParentheses encloses the number in parentheses, as in (1.234,56), which is common
+	// in accounting statements
+	NegativeParentheses
+	// NegativeCreditSuffix adds a credit suffix after the number, as in 1.234,56 CR. Positive
+	// numbers have no suffix.
+	NegativeCreditSuffix
+	// NegativeDebitSuffix adds a debit suffix after the number, as in 1.234,56 DR. Positive
+	// numbers have no suffix.
+	NegativeDebitSuffix
+)
+
+// affixes - Returns the text before and after the magnitude of a negative number marked with the style
+func (s NegativeStyle) affixes() (string, string) {
+	switch s {
+	case NegativeTrailingMinus:
+		return "", "-"
+	case NegativeParentheses:
+		return "(", ")"
+	case NegativeCreditSuffix:
+		return "", " CR"
+	case NegativeDebitSuffix:
+		return "", " DR"
+	default:
+		return "-", ""
+	}
+}
+
+// trim - Returns the start and end of the magnitude of a formatted number within value, without the
+// marks of a negative number of the style, and whether those marks were found. A leading minus is
+// read as a sign instead
+func (s NegativeStyle) trim(value string) (int, int, bool) {
+	prefix, suffix := s.affixes()
+	if s != NegativeLeadingMinus && len(value) > len(prefix)+len(suffix) &&
+		strings.HasPrefix(value, prefix) && strings.HasSuffix(value, suffix) {
+		return len(prefix), len(value) - len(suffix), true
+	}
+	return 0, len(value), false
+}
+
+// Formatter - Formats decimals for display and parses formatted strings back into decimals. The
+// formatting settings are kept apart from the decimals, so they aren't lost by arithmetic and the same
+// decimal can be shown to users of different locales. For example,
+// Formatter{DecimalPoint: '.', ThousandSeparator: ','}.Format(d) formats 2334599 with precision 2 as
+// '23,345.99'.
+type Formatter struct {
+	// DecimalPoint separates the whole part from the fraction. A zero DecimalPoint is formatted as
+	// DEFAULT_DECIMAL_POINT.
+	DecimalPoint byte
+	// ThousandSeparator separates the groups of digits of the whole part. A zero ThousandSeparator
+	// formats the whole part without grouping.
+	ThousandSeparator byte
+	// Grouping is the size of the groups of digits of the whole part from the right, where the last
+	// size repeats. nil groups by three, as in 1,234,567, while []int{3, 2} groups as in 12,34,567.
+	Grouping []int
+	// NegativeStyle determines how negative decimals are marked
+	NegativeStyle NegativeStyle
+}
+
+// DefaultFormatter - The formatter used by ToStringFormatted, with decimal point ',' and thousand
+// separator '.'
+var DefaultFormatter = Formatter{DecimalPoint: DEFAULT_DECIMAL_POINT, ThousandSeparator: DEFAULT_THOUSAND_SEPARATOR}
+
+// localeFormatters - The formatters returned by LocaleFormatter, by language tag
+var localeFormatters = map[string]Formatter{
+	"en-US": {DecimalPoint: '.', ThousandSeparator: ','},
+	"en-GB": {DecimalPoint: '.', ThousandSeparator: ','},
+	"en-IN": {DecimalPoint: '.', ThousandSeparator: ',', Grouping: []int{3, 2}},
+	"de-DE": {DecimalPoint: ',', ThousandSeparator: '.'},
+	"de-CH": {DecimalPoint: '.', ThousandSeparator: '\''},
+	"el-GR": {DecimalPoint: ',', ThousandSeparator: '.'},
+	"es-ES": {DecimalPoint: ',', ThousandSeparator: '.'},
+	"fr-FR": {DecimalPoint: ',', ThousandSeparator: ' '},
+	"it-IT": {DecimalPoint: ',', ThousandSeparator: '.'},
+	"ja-JP": {DecimalPoint: '.', ThousandSeparator: ','},
+}
+
+// LocaleFormatter - Returns the formatter for the language tag, like "en-US" or "de-DE", and
+// whether the locale is known. Locales that use non-ASCII separators get the closest ASCII ones,
+// so "fr-FR" uses a space as thousand separator. The formatter is a copy, so changing it doesn't
+// change the formatters returned later.
+func LocaleFormatter(tag string) (Formatter, bool) {
+	f, ok := localeFormatters[tag]
+	f.Grouping = append([]int(nil), f.Grouping...)
+	return f, ok
+}
+
+// Format - Returns the decimal as a string formatted with the separators, grouping and negative
+// style of the formatter. A decimal without a fraction has no decimal point, as in ToString
+func (f Formatter) Format(d Decimal) string {
+	var buf [64]byte
+	return string(d.AppendFormatted(buf[:0], f))
+}
+
+// AppendFormatted - Appends the decimal formatted with the formatter, as returned by Format, to dst
+// and returns the extended buffer. It doesn't allocate unless dst is too small or the coefficient
+// doesn't fit in an uint64
+func (d Decimal) AppendFormatted(dst []byte, f Formatter) []byte {
+	if !d.IsNegative() {
+		return d.appendMagnitude(dst, f)
+	}
+	prefix, suffix := f.NegativeStyle.affixes()
+	dst = append(dst, prefix...)
+	dst = d.appendMagnitude(dst, f)
+	return append(dst, suffix...)
+}
+
+// appendGrouped - Appends the digits of the whole part to dst with thousand separators between the
+// groups
+func (f Formatter) appendGrouped(dst, whole []byte) []byte {
+	if f.ThousandSeparator == 0 {
+		return append(dst, whole...)
+	}
+	// Find the size of the leftmost group, counting the groups from the right, then write the groups
+	// from the left
+	n, i := len(whole), 0
+	for n > f.groupSize(i) {
+		n -= f.groupSize(i)
+		i++
+	}
+	dst = append(dst, whole[:n]...)
+	for n < len(whole) {
+		i--
+		size := f.groupSize(i)
+		dst = append(dst, f.ThousandSeparator)
+		dst = append(dst, whole[n:n+size]...)
+		n += size
+	}
+	return dst
+}
+
+// groupSize - Returns the size of the i-th group of digits of the whole part, counting from the right
+func (f Formatter) groupSize(i int) int {
+	if len(f.Grouping) == 0 {
+		return 3
+	}
+	if i >= len(f.Grouping) {
+		i = len(f.Grouping) - 1
+	}
+	if f.Grouping[i] <= 0 {
+		// A group of no digits would never end
+		return 3
+	}
+	return f.Grouping[i]
+}
+
+// decimalPoint - Returns the decimal point of the formatter
+func (f Formatter) decimalPoint() byte {
+	if f.DecimalPoint == 0 {
+		return DEFAULT_DECIMAL_POINT
+	}
+	return f.DecimalPoint
+}
+
+// String - Returns the decimal as a string like ToString, implementing fmt.Stringer
+func (d Decimal) String() string {
+	return d.ToString()
+}
+
+// Format - Formats the decimal with its exact digits, implementing fmt.Formatter. The verbs %v, %s,
+// %f and %F print the decimal like ToString, %e and %E in scientific notation, like 1.050e+01 for
+// 10.50, and %q as a quoted string. A precision rounds the decimal half away from zero to that many
+// fraction digits, or digits after the decimal point of the mantissa for %e, so %.1f prints 2.675 as
+// 2.7. The flags '+' and ' ' mark positive decimals with a plus sign or a space, '-' pads on the
+// right and '0' pads with leading zeros after the sign.
+func (d Decimal) Format(s fmt.State, verb rune) {
+	precision, hasPrecision := s.Precision()
+	var body string
+	switch verb {
+	case 'v', 's', 'f', 'F':
+		if hasPrecision {
+			d = d.Round(uint(precision), RoundHalfUp)
+		}
+		body = d.Abs().ToString()
+	case 'e', 'E':
+		body = d.scientific(precision, hasPrecision, byte(verb))
+	case 'q':
+		pad(s, "", strconv.Quote(d.ToString()), false)
+		return
+	default:
+		fmt.Fprintf(s, "%%!%c(decimal.Decimal=%s)", verb, d.ToString())
+		return
+	}
+	sign := ""
+	switch {
+	case d.IsNegative():
+		sign = "-"
+	case s.Flag('+'):
+		sign = "+"
+	case s.Flag(' '):
+		sign = " "
+	}
+	pad(s, sign, body, true)
+}
+
+// scientific - Returns the magnitude of the decimal in scientific notation with the exponent marker e
+// or E. Without a precision the mantissa has all the digits of the coefficient
+func (d Decimal) scientific(precision int, hasPrecision bool, e byte) string {
+	m := d.magnitude()
+	digits := m.String()
+	exponent := len(digits) - 1 - int(d.precision)
+	if d.IsZero() {
+		exponent = 0
+	}
+	if hasPrecision {
+		if drop := len(digits) - (precision + 1); drop > 0 {
+			digits = bigDivRound(m, bigPow10(uint(drop)), RoundHalfUp).String()
+			// Rounding may carry into a new digit, e.g. 9.96 to 10.0
+			if len(digits) > precision+1 {
+				digits = digits[:precision+1]
+				exponent++
+			}
+		} else {
+			digits += strings.Repeat("0", -drop)
+		}
+	}
+	var b strings.Builder
+	b.WriteString(digits[:1])
+	if len(digits) > 1 {
+		b.WriteByte('.')
+		b.WriteString(digits[1:])
+	}
+	b.WriteByte(e)
+	if exponent < 0 {
+		b.WriteByte('-')
+		exponent = -exponent
+	} else {
+		b.WriteByte('+')
+	}
+	if exponent < 10 {
+		b.WriteByte('0')
+	}
+	b.WriteString(strconv.Itoa(exponent))
+	return b.String()
+}
+
+// pad - Writes the sign and the body of a formatted value padded to the width of the state. Numeric
+// values are padded with zeros after the sign when the '0' flag is set
+func pad(s fmt.State, sign, body string, numeric bool) {
+	width, ok := s.Width()
+	padding := width - len(sign) - len(body)
+	if !ok || padding <= 0 {
+		io.WriteString(s, sign+body)
+		return
+	}
+	switch {
+	case s.Flag('-'):
+		io.WriteString(s, sign+body+strings.Repeat(" ", padding))
+	case s.Flag('0') && numeric:
+		io.WriteString(s, sign+strings.Repeat("0", padding)+body)
+	default:
+		io.WriteString(s, strings.Repeat(" ", padding)+sign+body)
+	}
+}
@@ -0,0 +1,236 @@
+package decimal_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/petrossordinas/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatterNegativeStyle(t *testing.T) {
+	tests := []struct {
+		intVal    int64
+		precision uint
+		style     decimal.NegativeStyle
+		strVal    string
+	}{
+		{-123456, 2, decimal.NegativeLeadingMinus, "-1.234,56"},
+		{-123456, 2, decimal.NegativeTrailingMinus, "1.234,56-"},
+		{-123456, 2, decimal.NegativeParentheses, "(1.234,56)"},
+		{-123456, 2, decimal.NegativeCreditSuffix, "1.234,56 CR"},
+		{-123456, 2, decimal.NegativeDebitSuffix, "1.234,56 DR"},
+		{123456, 2, decimal.NegativeTrailingMinus, "1.234,56"},
+		{123456, 2, decimal.NegativeParentheses, "1.234,56"},
+		{123456, 2, decimal.NegativeCreditSuffix, "1.234,56"},
+		{0, 2, decimal.NegativeParentheses, "0,00"},
+		{-5, 2, decimal.NegativeParentheses, "(0,05)"},
+		{-5, 2, decimal.NegativeTrailingMinus, "0,05-"},
+		{-1234567, 0, decimal.NegativeLeadingMinus, "-1.234.567"},
+		{-1234567, 0, decimal.NegativeParentheses, "(1.234.567)"},
+		{-123, 0, decimal.NegativeLeadingMinus, "-123"},
+		{-123456789012, 3, decimal.NegativeDebitSuffix, "123.456.789,012 DR"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d := decimal.NewDecimal(tc.intVal, tc.precision)
+		f := decimal.DefaultFormatter
+		f.NegativeStyle = tc.style
+		assert.Equal(tc.strVal, f.Format(*d), "Test No: %d - Should be equal", testNo+1)
+		// Parsing the formatted string with the same formatter gives back the decimal
+		parsed, err := f.Parse(tc.strVal)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(*d, *parsed, "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestFormatterGrouping(t *testing.T) {
+	tests := []struct {
+		intVal    int64
+		precision uint
+		formatter decimal.Formatter
+		strVal    string
+	}{
+		{123456789, 2, decimal.Formatter{DecimalPoint: '.', ThousandSeparator: ','}, "1,234,567.89"},
+		{123456789, 2, decimal.Formatter{DecimalPoint: '.'}, "1234567.89"},
+		{123456789, 2, decimal.Formatter{ThousandSeparator: ' '}, "1 234 567,89"},
+		{123456789, 0, decimal.Formatter{DecimalPoint: '.', ThousandSeparator: ',', Grouping: []int{3, 2}}, "12,34,56,789"},
+		{-1234567, 2, decimal.Formatter{DecimalPoint: '.', ThousandSeparator: ',', Grouping: []int{3, 2}}, "-12,345.67"},
+		{123456789, 0, decimal.Formatter{DecimalPoint: '.', ThousandSeparator: ',', Grouping: []int{4}}, "1,2345,6789"},
+		{999, 0, decimal.Formatter{DecimalPoint: '.', ThousandSeparator: ',', Grouping: []int{3, 2}}, "999"},
+		{1000, 0, decimal.Formatter{DecimalPoint: '.', ThousandSeparator: ',', Grouping: []int{3, 2}}, "1,000"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d := decimal.NewDecimal(tc.intVal, tc.precision)
+		assert.Equal(tc.strVal, tc.formatter.Format(*d), "Test No: %d - Should be equal", testNo+1)
+		parsed, err := tc.formatter.Parse(tc.strVal)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(*d, *parsed, "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestFormatterParseErrors(t *testing.T) {
+	tests := []struct {
+		strVal    string
+		formatter decimal.Formatter
+	}{
+		{"12,345,67", decimal.Formatter{DecimalPoint: '.', ThousandSeparator: ',', Grouping: []int{3, 2}}},
+		{"1,23,4567", decimal.Formatter{DecimalPoint: '.', ThousandSeparator: ',', Grouping: []int{3, 2}}},
+		{"1234,567", decimal.Formatter{DecimalPoint: '.', ThousandSeparator: ',', Grouping: []int{3, 2}}},
+		{"1,234.5", decimal.Formatter{DecimalPoint: '.'}},
+		{"(-1,5)", decimal.Formatter{NegativeStyle: decimal.NegativeParentheses}},
+		{"(1,5", decimal.Formatter{NegativeStyle: decimal.NegativeParentheses}},
+		{"()", decimal.Formatter{NegativeStyle: decimal.NegativeParentheses}},
+		{"1,5 CR", decimal.Formatter{NegativeStyle: decimal.NegativeDebitSuffix}},
+		{"1,5-", decimal.Formatter{}},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, err := tc.formatter.Parse(tc.strVal)
+		assert.Nil(d, "Test No: %d - Was expecting nil", testNo+1)
+		assert.NotNil(err, "Test No: %d - Was expecting error", testNo+1)
+	}
+}
+
+func TestLocaleFormatter(t *testing.T) {
+	tests := []struct {
+		tag    string
+		strVal string
+	}{
+		{"en-US", "-1,234,567.89"},
+		{"de-DE", "-1.234.567,89"},
+		{"de-CH", "-1'234'567.89"},
+		{"fr-FR", "-1 234 567,89"},
+		{"en-IN", "-12,34,567.89"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		f, ok := decimal.LocaleFormatter(tc.tag)
+		assert.True(ok, "Test No: %d - Was expecting a formatter", testNo+1)
+		assert.Equal(tc.strVal, f.Format(*decimal.NewDecimal(-123456789, 2)), "Test No: %d - Should be equal", testNo+1)
+	}
+	_, ok := decimal.LocaleFormatter("xx-XX")
+	assert.False(ok)
+	// Changing a returned formatter doesn't change the next one
+	f, _ := decimal.LocaleFormatter("en-IN")
+	f.Grouping[0] = 1
+	f, _ = decimal.LocaleFormatter("en-IN")
+	assert.Equal("12,34,567.89", f.Format(*decimal.NewDecimal(123456789, 2)))
+}
+
+func TestFormattingSurvivesArithmetic(t *testing.T) {
+	assert := assert.New(t)
+	f := decimal.Formatter{DecimalPoint: '.', ThousandSeparator: ',', NegativeStyle: decimal.NegativeParentheses}
+	d := decimal.NewDecimal(123456, 2).Add(*decimal.NewDecimalFromFloat(-2000, 2))
+	assert.Equal("(765.44)", f.Format(d))
+	assert.Equal("-765,44", d.ToStringFormatted())
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		strVal string
+		result string
+	}{
+		{"%v", "10.50", "10.50"},
+		{"%s", "-10.50", "-10.50"},
+		{"%+v", "10.50", "+10.50"},
+		{"%+v", "-10.50", "-10.50"},
+		{"% v", "10.50", " 10.50"},
+		{"%f", "123456789012345678901234567890.5", "123456789012345678901234567890.5"},
+		{"%.2f", "2.675", "2.68"},
+		{"%.1f", "-2.675", "-2.7"},
+		{"%.4f", "2.5", "2.5000"},
+		{"%.0f", "2.5", "3"},
+		{"%.2f", "-0.001", "0.00"},
+		{"%10.2f", "-2.675", "     -2.68"},
+		{"%-10.2f|", "2.675", "2.68      |"},
+		{"%010.2f", "-2.675", "-000002.68"},
+		{"%+08.1F", "2.5", "+00002.5"},
+		{"%e", "10.50", "1.050e+01"},
+		{"%E", "-0.000123", "-1.23E-04"},
+		{"%.2e", "12345", "1.23e+04"},
+		{"%.2e", "99960", "1.00e+05"},
+		{"%.3e", "1.5", "1.500e+00"},
+		{"%.0e", "25", "3e+01"},
+		{"%e", "0.00", "0e+00"},
+		{"%e", "123456789012345678901234567890", "1.23456789012345678901234567890e+29"},
+		{"%q", "-10.50", `"-10.50"`},
+		{"%10q", "1.5", `     "1.5"`},
+		{"%d", "1.5", "%!d(decimal.Decimal=1.5)"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, _ := decimal.NewDecimalFromString(tc.strVal)
+		assert.Equal(tc.result, fmt.Sprintf(tc.format, *d), "Test No: %d - Should be equal", testNo+1)
+	}
+	// Pointers and nested values use the same formatting
+	d := decimal.NewDecimal(1050, 2)
+	assert.Equal("10.50", fmt.Sprint(d))
+	assert.Equal("[10.50 -1]", fmt.Sprint([]decimal.Decimal{*d, *decimal.NewDecimal(-1, 0)}))
+	assert.Equal("10.50", d.String())
+}
+
+func TestAppend(t *testing.T) {
+	tests := []string{"0", "-0.05", "10.50", "-1234567.891", "123456789012345678901234567890.12"}
+	assert := assert.New(t)
+	f := decimal.Formatter{DecimalPoint: '.', ThousandSeparator: ',', NegativeStyle: decimal.NegativeParentheses}
+	for testNo, tc := range tests {
+		d, _ := decimal.NewDecimalFromString(tc)
+		assert.Equal("x"+tc, string(d.AppendString([]byte("x"))), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal("x"+f.Format(*d), string(d.AppendFormatted([]byte("x"), f)), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestAppendDoesNotAllocate(t *testing.T) {
+	assert := assert.New(t)
+	d := decimal.NewDecimal(-123456789, 2)
+	f := decimal.Formatter{DecimalPoint: '.', ThousandSeparator: ',', Grouping: []int{3, 2}, NegativeStyle: decimal.NegativeParentheses}
+	buf := make([]byte, 0, 64)
+	assert.Zero(testing.AllocsPerRun(100, func() { buf = d.AppendString(buf[:0]) }))
+	assert.Zero(testing.AllocsPerRun(100, func() { buf = d.AppendFormatted(buf[:0], f) }))
+}
+
+func BenchmarkToString(b *testing.B) {
+	d := decimal.NewDecimal(-123456789, 2)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = d.ToString()
+	}
+}
+
+func BenchmarkAppendString(b *testing.B) {
+	d := decimal.NewDecimal(-123456789, 2)
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = d.AppendString(buf[:0])
+	}
+}
+
+func BenchmarkToStringFormatted(b *testing.B) {
+	d := decimal.NewDecimal(-123456789, 2)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = d.ToStringFormatted()
+	}
+}
+
+func BenchmarkAppendFormatted(b *testing.B) {
+	d := decimal.NewDecimal(-123456789, 2)
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = d.AppendFormatted(buf[:0], decimal.DefaultFormatter)
+	}
+}
+
+func BenchmarkAppendStringArbitraryPrecision(b *testing.B) {
+	d, _ := decimal.NewDecimalFromString("-123456789012345678901234567890.12")
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = d.AppendString(buf[:0])
+	}
+}
@@ -0,0 +1,284 @@
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrEmptyString is returned when parsing an empty string
+	ErrEmptyString = errors.New("empty string")
+	// ErrInvalidSyntax is returned when a string is not a valid decimal
+	ErrInvalidSyntax = errors.New("invalid syntax")
+	// ErrOutOfRange is returned when a value can't be represented by a decimal
+	ErrOutOfRange = errors.New("value out of range")
+)
+
+// NewDecimalFromString - Creates a new decimal from a string. The string may have a leading sign,
+// a decimal point and an exponent, for example "-1234.5600", "+0.07" or "1e-3". The precision is
+// inferred from the number of digits after the decimal point, adjusted by the exponent, so
+// "-1234.5600" has precision 4, "1e-3" has precision 3 and "1.5e2" has precision 0.
+func NewDecimalFromString(value string) (*Decimal, error) {
+	n, err := parseNumber(value)
+	if err != nil {
+		return nil, err
+	}
+	precision := uint(0)
+	if n.scale > 0 {
+		precision = uint(n.scale)
+	}
+	return n.toDecimal(precision, RoundHalfUp)
+}
+
+// NewDecimalFromStringWithPrecision - Creates a new decimal from a string with the given precision.
+// The string has the same format as in NewDecimalFromString. If the string has more fractional digits
+// than the precision, the value is rounded half away from zero, so "2.675" with precision 2 is 2.68.
+func NewDecimalFromStringWithPrecision(value string, precision uint) (*Decimal, error) {
+	return NewDecimalFromStringWithRounding(value, precision, RoundHalfUp)
+}
+
+// NewDecimalFromStringWithRounding - Creates a new decimal from a string with the given precision like
+// NewDecimalFromStringWithPrecision, rounding digits beyond the precision using the rounding mode.
+// For example, "2.665" with precision 2 and RoundHalfEven is 2.66
+func NewDecimalFromStringWithRounding(value string, precision uint, mode RoundingMode) (*Decimal, error) {
+	n, err := parseNumber(value)
+	if err != nil {
+		return nil, err
+	}
+	return n.toDecimal(precision, mode)
+}
+
+// NewDecimalFromFormattedString - Creates a new decimal from a string formatted with the given decimal
+// point and thousand separator, as returned by ToStringFormatted. For decimal point ',' and thousand
+// separator '.' the string "23.345,99" results in a decimal with value 23345.99 and precision 2. It is
+// a shorthand for Formatter{DecimalPoint: decimalPoint, ThousandSeparator: thousandSeparator}.Parse
+func NewDecimalFromFormattedString(value string, decimalPoint, thousandSeparator byte) (*Decimal, error) {
+	return Formatter{DecimalPoint: decimalPoint, ThousandSeparator: thousandSeparator}.Parse(value)
+}
+
+// Parse - Creates a new decimal from a string formatted with the separators, grouping and negative
+// style of the formatter, as returned by Format. The precision is inferred from the number of digits
+// after the decimal point. Thousand separators are optional, but when present they must separate every
+// group of digits of the whole part, so with the default grouping "1.234,5" and "1234,5" are accepted
+// while "12.34,5" and "1.2345,5" are rejected. A leading sign is accepted unless the string is marked
+// as negative by the negative style.
+func (f Formatter) Parse(value string) (*Decimal, error) {
+	decimalPoint, thousandSeparator := f.decimalPoint(), f.ThousandSeparator
+	if !isSeparator(decimalPoint) || (thousandSeparator != 0 && (thousandSeparator == decimalPoint || !isSeparator(thousandSeparator))) {
+		return nil, fmt.Errorf("parse decimal %q: invalid separators %q and %q", value, decimalPoint, thousandSeparator)
+	}
+	if value == "" {
+		return nil, fmt.Errorf("parse decimal: %w", ErrEmptyString)
+	}
+	i, end, negative := f.NegativeStyle.trim(value)
+	n := number{input: value, negative: negative}
+	if !negative && (value[i] == '+' || value[i] == '-') {
+		n.negative = value[i] == '-'
+		i++
+	}
+	// Read the whole part, remembering the position of every thousand separator and the number of
+	// digits before it, so that the groups can be checked from the right
+	digits := make([]byte, 0, len(value))
+	var separators, grouped []int
+	for ; i < end; i++ {
+		c := value[i]
+		if isDigit(c) {
+			digits = append(digits, c)
+			continue
+		}
+		if thousandSeparator == 0 || c != thousandSeparator {
+			break
+		}
+		separators = append(separators, i)
+		grouped = append(grouped, len(digits))
+	}
+	if len(digits) == 0 {
+		return nil, n.syntaxError(i, "expected digit")
+	}
+	groupEnd := len(digits)
+	for k := len(separators) - 1; k >= 0; k-- {
+		size := f.groupSize(len(separators) - 1 - k)
+		if groupEnd-grouped[k] == size {
+			groupEnd = grouped[k]
+			continue
+		}
+		if k == len(separators)-1 {
+			return nil, n.syntaxError(i, fmt.Sprintf("expected group of %d digits", size))
+		}
+		return nil, n.syntaxError(separators[k], "misplaced thousand separator")
+	}
+	// The leftmost group may be shorter than the others, but not empty
+	if len(separators) > 0 && (groupEnd == 0 || groupEnd > f.groupSize(len(separators))) {
+		return nil, n.syntaxError(separators[0], "misplaced thousand separator")
+	}
+	if i < end && value[i] == decimalPoint {
+		i++
+		start := i
+		for i < end && isDigit(value[i]) {
+			i++
+		}
+		if i == start {
+			return nil, n.syntaxError(i, "expected digit after decimal point")
+		}
+		digits = append(digits, value[start:i]...)
+		n.scale = i - start
+	}
+	if i < end {
+		return nil, n.syntaxError(i, "unexpected character")
+	}
+	n.digits = string(digits)
+	return n.toDecimal(uint(n.scale), RoundHalfUp)
+}
+
+// number - A parsed decimal string with value (-1)^negative * digits * 10^-scale
+type number struct {
+	input    string
+	negative bool
+	digits   string
+	scale    int
+}
+
+// parseNumber - Parses a string of the form [sign]digits[.digits][(e|E)[sign]digits]
+func parseNumber(s string) (number, error) {
+	n := number{input: s}
+	if s == "" {
+		return n, fmt.Errorf("parse decimal: %w", ErrEmptyString)
+	}
+	i := 0
+	if s[i] == '+' || s[i] == '-' {
+		n.negative = s[i] == '-'
+		i++
+	}
+	// Read the whole part, then the fractional part. The digits of both are kept together and the
+	// number of fractional digits becomes the scale.
+	start := i
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	if i == start {
+		return n, n.syntaxError(i, "expected digit")
+	}
+	n.digits = s[start:i]
+	if i < len(s) && s[i] == '.' {
+		i++
+		start = i
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+		if i == start {
+			return n, n.syntaxError(i, "expected digit after decimal point")
+		}
+		n.digits += s[start:i]
+		n.scale = i - start
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		start = i
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+		if i == start || !isDigit(s[i-1]) {
+			return n, n.syntaxError(i, "expected digit in exponent")
+		}
+		exp, err := strconv.Atoi(s[start:i])
+		if err != nil || exp > math.MaxInt32 || exp < math.MinInt32 {
+			return n, fmt.Errorf("parse decimal %q: %w: exponent %s", s, ErrOutOfRange, s[start:i])
+		}
+		n.scale -= exp
+	}
+	if i < len(s) {
+		return n, n.syntaxError(i, "unexpected character")
+	}
+	return n, nil
+}
+
+// maxParseDigits - The largest number of digits in the coefficient, and the largest precision, of a
+// parsed decimal. It protects against strings like "1e-999999999" that would need huge amounts of memory
+const maxParseDigits = 10000
+
+// toDecimal - Converts the parsed number to a decimal with the given precision. Digits beyond the
+// precision are rounded using the rounding mode.
+func (n number) toDecimal(precision uint, mode RoundingMode) (*Decimal, error) {
+	if precision > maxParseDigits {
+		return nil, fmt.Errorf("parse decimal %q: %w: precision %d exceeds %d", n.input, ErrOutOfRange, precision, maxParseDigits)
+	}
+	digits := strings.TrimLeft(n.digits, "0")
+	shift := int(precision) - n.scale
+	discarded := ""
+	if shift < 0 {
+		// Drop the digits beyond the precision. When all digits are dropped, the discarded part
+		// starts with an implied zero.
+		drop := -shift
+		if drop <= len(digits) {
+			discarded = digits[len(digits)-drop:]
+			digits = digits[:len(digits)-drop]
+		} else {
+			discarded = "0" + digits
+			digits = ""
+		}
+		shift = 0
+	}
+	roundUp := false
+	if strings.Trim(discarded, "0") != "" {
+		// Compare the discarded digits to half a unit of the last digit kept
+		half := -1
+		if discarded[0] > '5' || (discarded[0] == '5' && strings.Trim(discarded[1:], "0") != "") {
+			half = 1
+		} else if discarded[0] == '5' {
+			half = 0
+		}
+		lastDigit := uint64(0)
+		if digits != "" {
+			lastDigit = uint64(digits[len(digits)-1] - '0')
+		}
+		roundUp = mode.roundsAway(n.negative, lastDigit, half)
+	}
+	// Append the zeros required by the precision. Zero stays zero however large the exponent is.
+	if shift > 0 && digits != "" {
+		if len(digits)+shift > maxParseDigits {
+			return nil, fmt.Errorf("parse decimal %q: %w: more than %d digits", n.input, ErrOutOfRange, maxParseDigits)
+		}
+		digits += strings.Repeat("0", shift)
+	}
+	d := NewDecimal(0, precision)
+	if len(digits) <= maxSupportedPrecision {
+		d.coefficient, _ = strconv.ParseUint("0"+digits, 10, 64)
+		if roundUp {
+			d.coefficient++
+		}
+	} else {
+		// The coefficient may not fit in an uint64
+		m, _ := new(big.Int).SetString(digits, 10)
+		if roundUp {
+			m.Add(m, big.NewInt(1))
+		}
+		d.setMagnitude(m)
+	}
+	d.negative = n.negative && !d.IsZero()
+	return d, nil
+}
+
+// syntaxError - Returns an error describing invalid input at position i
+func (n number) syntaxError(i int, reason string) error {
+	if i < len(n.input) {
+		return fmt.Errorf("parse decimal %q: %w: %s, found %q at position %d", n.input, ErrInvalidSyntax, reason, n.input[i], i)
+	}
+	return fmt.Errorf("parse decimal %q: %w: %s at end of input", n.input, ErrInvalidSyntax, reason)
+}
+
+// isDigit - Returns true if c is an ASCII digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isSeparator - Returns true if c can be used as a decimal point or thousand separator
+func isSeparator(c byte) bool {
+	return c != 0 && c != '+' && c != '-' && !isDigit(c)
+}
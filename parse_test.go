@@ -0,0 +1,232 @@
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/petrossordinas/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDecimalFromString(t *testing.T) {
+	tests := []struct {
+		strVal    string
+		intVal    int64
+		precision uint
+	}{
+		{"-1234.5600", -12345600, 4},
+		{"+0.07", 7, 2},
+		{"1e-3", 1, 3},
+		{"1E3", 1000, 0},
+		{"1.5e2", 150, 0},
+		{"1.25e1", 125, 1},
+		{"-0.5e+1", -5, 0},
+		{"007", 7, 0},
+		{"0", 0, 0},
+		{"0.000", 0, 3},
+		{"0e99999", 0, 0},
+		{"9223372036854775807", 9223372036854775807, 0},
+		{"-9223372036854775808", -9223372036854775808, 0},
+		{"922337203.6854775807", 9223372036854775807, 10},
+		{"3.14159265358979", 314159265358979, 14},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, err := decimal.NewDecimalFromString(tc.strVal)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(*decimal.NewDecimal(tc.intVal, tc.precision), *d, "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.precision, d.GetPrecision(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestNewDecimalFromStringArbitraryPrecision(t *testing.T) {
+	tests := []struct {
+		strVal    string
+		precision uint
+		result    string
+	}{
+		{"9223372036854775808", 0, "9223372036854775808"},
+		{"-9223372036854775809", 0, "-9223372036854775809"},
+		{"1e19", 0, "10000000000000000000"},
+		{"1e-19", 19, "0.0000000000000000001"},
+		{"123456789012345678901234567890.123456789012345678", 18, "123456789012345678901234567890.123456789012345678"},
+		{"-0.000000000000000000000000000001", 30, "-0.000000000000000000000000000001"},
+		{"0.000000000000000000000000000000", 30, "0.000000000000000000000000000000"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, err := decimal.NewDecimalFromString(tc.strVal)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(tc.precision, d.GetPrecision(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.result, d.ToString(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestNewDecimalFromStringWithPrecision(t *testing.T) {
+	tests := []struct {
+		strVal    string
+		precision uint
+		intVal    int64
+	}{
+		{"2.675", 2, 268},
+		{"-2.675", 2, -268},
+		{"2.6749999999999999999999", 2, 267},
+		{"148.495049", 2, 14850},
+		{"12", 2, 1200},
+		{"1e-3", 2, 0},
+		{"5e-3", 2, 1},
+		{"0.0049", 2, 0},
+		{"12.5", 0, 13},
+		{"1.5e2", 1, 1500},
+		{"0.000000000000000000000000001", 4, 0},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, err := decimal.NewDecimalFromStringWithPrecision(tc.strVal, tc.precision)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(*decimal.NewDecimal(tc.intVal, tc.precision), *d, "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestNewDecimalFromStringWithRounding(t *testing.T) {
+	tests := []struct {
+		strVal    string
+		precision uint
+		mode      decimal.RoundingMode
+		intVal    int64
+	}{
+		{"2.665", 2, decimal.RoundHalfEven, 266},
+		{"2.66500000000000000001", 2, decimal.RoundHalfEven, 267},
+		{"2.675", 2, decimal.RoundHalfEven, 268},
+		{"2.675", 2, decimal.RoundHalfDown, 267},
+		{"-2.671", 2, decimal.RoundCeiling, -267},
+		{"-2.671", 2, decimal.RoundFloor, -268},
+		{"2.679", 2, decimal.RoundDown, 267},
+		{"2.6700000000000000000001", 2, decimal.RoundUp, 268},
+		{"2.651", 2, decimal.Round05Up, 266},
+		{"0.001", 0, decimal.RoundUp, 1},
+		{"0.001", 0, decimal.Round05Up, 1},
+		{"1e-30", 0, decimal.RoundCeiling, 1},
+		{"-1e-30", 0, decimal.RoundCeiling, 0},
+		{"2.6700", 2, decimal.RoundUp, 267},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, err := decimal.NewDecimalFromStringWithRounding(tc.strVal, tc.precision, tc.mode)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(tc.intVal, d.ToInt(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestNewDecimalFromStringErrors(t *testing.T) {
+	tests := []struct {
+		strVal string
+		err    error
+	}{
+		{"", decimal.ErrEmptyString},
+		{"-", decimal.ErrInvalidSyntax},
+		{"abc", decimal.ErrInvalidSyntax},
+		{"12.", decimal.ErrInvalidSyntax},
+		{".5", decimal.ErrInvalidSyntax},
+		{"1.2.3", decimal.ErrInvalidSyntax},
+		{"1,5", decimal.ErrInvalidSyntax},
+		{" 12", decimal.ErrInvalidSyntax},
+		{"12 ", decimal.ErrInvalidSyntax},
+		{"1e", decimal.ErrInvalidSyntax},
+		{"1e+", decimal.ErrInvalidSyntax},
+		{"--1", decimal.ErrInvalidSyntax},
+		{"1e99999999999", decimal.ErrOutOfRange},
+		{"1e-10001", decimal.ErrOutOfRange},
+		{"1e10000", decimal.ErrOutOfRange},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, err := decimal.NewDecimalFromString(tc.strVal)
+		assert.Nil(d, "Test No: %d - Was expecting nil", testNo+1)
+		assert.ErrorIs(err, tc.err, "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestNewDecimalFromFormattedString(t *testing.T) {
+	tests := []struct {
+		strVal            string
+		decimalPoint      byte
+		thousandSeparator byte
+		intVal            int64
+		precision         uint
+	}{
+		{"23.345,99", ',', '.', 2334599, 2},
+		{"23,345.99", '.', ',', 2334599, 2},
+		{"1.234", ',', '.', 1234, 0},
+		{"1,234", '.', ',', 1234, 0},
+		{"1234,5", ',', '.', 12345, 1},
+		{"-3.894.873,7383", ',', '.', -38948737383, 4},
+		{"+0,07", ',', '.', 7, 2},
+		{"1 000 000.50", '.', ' ', 100000050, 2},
+		{"1'234'567", '.', '\'', 1234567, 0},
+		{"0,0", ',', '.', 0, 1},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, err := decimal.NewDecimalFromFormattedString(tc.strVal, tc.decimalPoint, tc.thousandSeparator)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(tc.intVal, d.ToInt(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.precision, d.GetPrecision(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestNewDecimalFromFormattedStringRoundTrip(t *testing.T) {
+	tests := []struct {
+		intVal            int64
+		precision         uint
+		decimalPoint      byte
+		thousandSeparator byte
+	}{
+		{2334599, 2, ',', '.'},
+		{38948737383, 4, '.', ','},
+		{314159265358979, 14, ',', '.'},
+		{1234567, 0, ',', '.'},
+		{999, 0, '.', ','},
+		{1000, 0, '.', ','},
+		{18, 2, ',', '.'},
+		{0, 1, ',', '.'},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d := decimal.NewDecimal(tc.intVal, tc.precision)
+		f := decimal.Formatter{DecimalPoint: tc.decimalPoint, ThousandSeparator: tc.thousandSeparator}
+		parsed, err := decimal.NewDecimalFromFormattedString(f.Format(*d), tc.decimalPoint, tc.thousandSeparator)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(*d, *parsed, "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(f.Format(*d), f.Format(*parsed), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestNewDecimalFromFormattedStringErrors(t *testing.T) {
+	tests := []struct {
+		strVal            string
+		decimalPoint      byte
+		thousandSeparator byte
+	}{
+		{"", ',', '.'},
+		{"1.23", ',', '.'},
+		{"12.34,5", ',', '.'},
+		{"1.2345,5", ',', '.'},
+		{"1234.567", ',', '.'},
+		{"1..234", ',', '.'},
+		{".234", ',', '.'},
+		{"1.234.", ',', '.'},
+		{"1,234,5", ',', '.'},
+		{"1,", ',', '.'},
+		{"1,2.3", ',', '.'},
+		{"1.234,5", '.', ','},
+		{"abc", ',', '.'},
+		{"1,5", ',', ','},
+		{"1,5", ',', '5'},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, err := decimal.NewDecimalFromFormattedString(tc.strVal, tc.decimalPoint, tc.thousandSeparator)
+		assert.Nil(d, "Test No: %d - Was expecting nil", testNo+1)
+		assert.NotNil(err, "Test No: %d - Was expecting error", testNo+1)
+	}
+}
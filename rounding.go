@@ -0,0 +1,101 @@
+package decimal
+
+// RoundingMode - Determines how a value is rounded when digits beyond the precision are discarded
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest value and ties away from zero, so 2.675 becomes 2.68 and
+	// -2.675 becomes -2.68. This is the rounding mode used when none is given.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds to the nearest value and ties to the even neighbour, also known as
+	// banker's rounding, so 2.665 becomes 2.66 and 2.675 becomes 2.68
+	RoundHalfEven
+	// RoundHalfDown rounds to the nearest value and ties towards zero, so 2.675 becomes 2.67
+	RoundHalfDown
+	// RoundCeiling rounds towards positive infinity, so 2.671 becomes 2.68 and -2.679 becomes -2.67
+	RoundCeiling
+	// RoundFloor rounds towards negative infinity, so 2.679 becomes 2.67 and -2.671 becomes -2.68
+	RoundFloor
+	// RoundDown rounds towards zero, i.e. truncates, so 2.679 becomes 2.67 and -2.679 becomes -2.67
+	RoundDown
+	// RoundUp rounds away from zero, so 2.671 becomes 2.68 and -2.671 becomes -2.68
+	RoundUp
+	// Round05Up rounds towards zero, unless the last digit kept would be 0 or 5, in which case it rounds
+	// away from zero, so 2.671 becomes 2.67 and 2.651 becomes 2.66
+	Round05Up
+)
+
+// Round - Returns the decimal rounded to the given precision using the rounding mode. If the precision
+// is larger than the precision of the decimal, the fraction is padded with zeros.
+// For example, 2.675 rounded to precision 2 with RoundHalfEven is 2.68 and with RoundDown 2.67
+func (d Decimal) Round(precision uint, mode RoundingMode) Decimal {
+	value, okV := d.toInt()
+	if rounded, ok := rescale(value, d.precision, precision, mode); okV && ok {
+		return *NewDecimal(rounded, precision)
+	}
+	// The decimal doesn't fit in an int64 at the precision, so round it with arbitrary precision
+	if precision >= d.precision {
+		return *newDecimalFromBigInt(d.bigIntAt(precision), precision)
+	}
+	return *newDecimalFromBigInt(bigDivRound(d.bigInt(), bigPow10(d.precision-precision), mode), precision)
+}
+
+// Rescale - Returns the decimal at the given precision like Round, and whether the value is unchanged.
+// exact is false when rounding discarded non-zero digits, so 2.675 rescaled to precision 2 is 2.68 and
+// not exact, while 2.670 rescaled to precision 2 is 2.67 and exact.
+func (d Decimal) Rescale(precision uint, mode RoundingMode) (result Decimal, exact bool) {
+	result = d.Round(precision, mode)
+	return result, precision >= d.precision || result.Equal(d)
+}
+
+// Floor - Returns the decimal rounded towards negative infinity to the given precision, so 2.679
+// becomes 2.67 and -2.671 becomes -2.68 at precision 2
+func (d Decimal) Floor(precision uint) Decimal {
+	return d.Round(precision, RoundFloor)
+}
+
+// Ceil - Returns the decimal rounded towards positive infinity to the given precision, so 2.671
+// becomes 2.68 and -2.679 becomes -2.67 at precision 2
+func (d Decimal) Ceil(precision uint) Decimal {
+	return d.Round(precision, RoundCeiling)
+}
+
+// Truncate - Returns the decimal rounded towards zero to the given precision, i.e. with the digits
+// beyond the precision dropped, so 2.679 becomes 2.67 and -2.679 becomes -2.67 at precision 2
+func (d Decimal) Truncate(precision uint) Decimal {
+	return d.Round(precision, RoundDown)
+}
+
+// RoundToIncrement - Returns the decimal rounded to a multiple of the increment using the rounding
+// mode, and whether the value is unchanged. For example, 12.43 rounded to 0.05 with RoundHalfUp is
+// 12.45, as in Swiss cash rounding. The sign of the increment is ignored and the result has the
+// precision of the decimal with the largest precision. RoundToIncrement panics if the increment is zero.
+func (d Decimal) RoundToIncrement(increment Decimal, mode RoundingMode) (result Decimal, exact bool) {
+	increment.negative = false
+	_, remainder := d.QuoRemWithRounding(increment, mode)
+	return d.Subtract(remainder), remainder.IsZero()
+}
+
+// roundsAway - Returns true if a value whose discarded digits are not all zero must be rounded away
+// from zero. lastDigit is the least significant digit kept and half is -1, 0 or 1 when the discarded
+// digits are less than, equal to or more than half of a unit of the last digit kept.
+func (m RoundingMode) roundsAway(negative bool, lastDigit uint64, half int) bool {
+	switch m {
+	case RoundHalfEven:
+		return half > 0 || (half == 0 && lastDigit%2 == 1)
+	case RoundHalfDown:
+		return half > 0
+	case RoundCeiling:
+		return !negative
+	case RoundFloor:
+		return negative
+	case RoundDown:
+		return false
+	case RoundUp:
+		return true
+	case Round05Up:
+		return lastDigit == 0 || lastDigit == 5
+	default:
+		return half >= 0
+	}
+}
@@ -0,0 +1,141 @@
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/petrossordinas/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		mode   decimal.RoundingMode
+		result []string
+	}{
+		// Results for 2.665, 2.675, 2.671, 2.679, 2.651, -2.665, -2.675, -2.671, -2.679 and 2.600
+		{decimal.RoundHalfUp, []string{"2.67", "2.68", "2.67", "2.68", "2.65", "-2.67", "-2.68", "-2.67", "-2.68", "2.60"}},
+		{decimal.RoundHalfEven, []string{"2.66", "2.68", "2.67", "2.68", "2.65", "-2.66", "-2.68", "-2.67", "-2.68", "2.60"}},
+		{decimal.RoundHalfDown, []string{"2.66", "2.67", "2.67", "2.68", "2.65", "-2.66", "-2.67", "-2.67", "-2.68", "2.60"}},
+		{decimal.RoundCeiling, []string{"2.67", "2.68", "2.68", "2.68", "2.66", "-2.66", "-2.67", "-2.67", "-2.67", "2.60"}},
+		{decimal.RoundFloor, []string{"2.66", "2.67", "2.67", "2.67", "2.65", "-2.67", "-2.68", "-2.68", "-2.68", "2.60"}},
+		{decimal.RoundDown, []string{"2.66", "2.67", "2.67", "2.67", "2.65", "-2.66", "-2.67", "-2.67", "-2.67", "2.60"}},
+		{decimal.RoundUp, []string{"2.67", "2.68", "2.68", "2.68", "2.66", "-2.67", "-2.68", "-2.68", "-2.68", "2.60"}},
+		{decimal.Round05Up, []string{"2.66", "2.67", "2.67", "2.67", "2.66", "-2.66", "-2.67", "-2.67", "-2.67", "2.60"}},
+	}
+	values := []int64{2665, 2675, 2671, 2679, 2651, -2665, -2675, -2671, -2679, 2600}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		for i, v := range values {
+			d := decimal.NewDecimal(v, 3)
+			assert.Equal(tc.result[i], d.Round(2, tc.mode).ToString(), "Test No: %d.%d - Should be equal", testNo+1, i+1)
+		}
+	}
+}
+
+func TestRoundPrecision(t *testing.T) {
+	tests := []struct {
+		intVal    int64
+		precision uint
+		toPrec    uint
+		mode      decimal.RoundingMode
+		result    string
+	}{
+		{2675, 3, 5, decimal.RoundHalfUp, "2.67500"},
+		{2675, 3, 3, decimal.RoundDown, "2.675"},
+		{2500, 3, 0, decimal.RoundHalfEven, "2"},
+		{3500, 3, 0, decimal.RoundHalfEven, "4"},
+		{-2500, 3, 0, decimal.RoundHalfEven, "-2"},
+		{999, 3, 2, decimal.RoundHalfUp, "1.00"},
+		{1, 3, 0, decimal.Round05Up, "1"},
+		{1001, 3, 0, decimal.Round05Up, "1"},
+		{5001, 3, 0, decimal.Round05Up, "6"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d := decimal.NewDecimal(tc.intVal, tc.precision)
+		r := d.Round(tc.toPrec, tc.mode)
+		assert.Equal(tc.result, r.ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.toPrec, r.GetPrecision(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestRescale(t *testing.T) {
+	tests := []struct {
+		strVal    string
+		precision uint
+		mode      decimal.RoundingMode
+		result    string
+		exact     bool
+	}{
+		{"2.675", 2, decimal.RoundHalfUp, "2.68", false},
+		{"2.670", 2, decimal.RoundHalfUp, "2.67", true},
+		{"2.675", 5, decimal.RoundHalfUp, "2.67500", true},
+		{"-2.675", 0, decimal.RoundDown, "-2", false},
+		{"0.001", 2, decimal.RoundHalfEven, "0.00", false},
+		{"123456789012345678901234567890.500", 1, decimal.RoundHalfUp, "123456789012345678901234567890.5", true},
+		{"123456789012345678901234567890.500", 0, decimal.RoundHalfEven, "123456789012345678901234567890", false},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, _ := decimal.NewDecimalFromString(tc.strVal)
+		result, exact := d.Rescale(tc.precision, tc.mode)
+		assert.Equal(tc.result, result.ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.precision, result.GetPrecision(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.exact, exact, "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestFloorCeilTruncate(t *testing.T) {
+	tests := []struct {
+		strVal    string
+		precision uint
+		floor     string
+		ceil      string
+		truncate  string
+	}{
+		{"2.671", 2, "2.67", "2.68", "2.67"},
+		{"-2.671", 2, "-2.68", "-2.67", "-2.67"},
+		{"2.5", 0, "2", "3", "2"},
+		{"-2.5", 0, "-3", "-2", "-2"},
+		{"-0.5", 0, "-1", "0", "0"},
+		{"3.000", 1, "3.0", "3.0", "3.0"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, _ := decimal.NewDecimalFromString(tc.strVal)
+		assert.Equal(tc.floor, d.Floor(tc.precision).ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.ceil, d.Ceil(tc.precision).ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.truncate, d.Truncate(tc.precision).ToString(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestRoundToIncrement(t *testing.T) {
+	tests := []struct {
+		strVal    string
+		increment string
+		mode      decimal.RoundingMode
+		result    string
+		exact     bool
+	}{
+		{"12.43", "0.05", decimal.RoundHalfUp, "12.45", false},
+		{"12.42", "0.05", decimal.RoundHalfUp, "12.40", false},
+		{"12.425", "0.05", decimal.RoundHalfUp, "12.450", false},
+		{"12.425", "0.05", decimal.RoundHalfDown, "12.400", false},
+		{"-12.43", "0.05", decimal.RoundHalfUp, "-12.45", false},
+		{"12.45", "0.05", decimal.RoundHalfUp, "12.45", true},
+		{"101.13", "0.25", decimal.RoundFloor, "101.00", false},
+		{"101.13", "0.25", decimal.RoundCeiling, "101.25", false},
+		{"-101.13", "0.25", decimal.RoundFloor, "-101.25", false},
+		{"101.13", "-0.25", decimal.RoundFloor, "101.00", false},
+		{"7", "0.5", decimal.RoundDown, "7.0", true},
+		{"1234", "100", decimal.RoundHalfEven, "1200", false},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d, _ := decimal.NewDecimalFromString(tc.strVal)
+		increment, _ := decimal.NewDecimalFromString(tc.increment)
+		result, exact := d.RoundToIncrement(*increment, tc.mode)
+		assert.Equal(tc.result, result.ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.Equal(tc.exact, exact, "Test No: %d - Should be equal", testNo+1)
+	}
+}
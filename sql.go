@@ -0,0 +1,73 @@
+package decimal
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// Scan - Sets the decimal to a value read from a database, implementing sql.Scanner. The value may be
+// a string or []byte, as drivers return NUMERIC and DECIMAL columns, an int64 or a float64, which is
+// taken as the shortest decimal that converts back to it. The decimal always gets the exact digits and
+// the precision of the value, since the column already has a scale, so a decimal can be reused to scan
+// many rows. NULL can't be scanned into a Decimal, use NullDecimal for nullable columns
+func (d *Decimal) Scan(src any) error {
+	var value string
+	switch v := src.(type) {
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	case int64:
+		value = strconv.FormatInt(v, 10)
+	case float64:
+		value = strconv.FormatFloat(v, 'g', -1, 64)
+	case nil:
+		return fmt.Errorf("scan decimal: can't scan NULL, use NullDecimal")
+	default:
+		return fmt.Errorf("scan decimal: unsupported type %T", src)
+	}
+	dc, err := NewDecimalFromString(value)
+	if err != nil {
+		return fmt.Errorf("scan decimal: %w", err)
+	}
+	*d = *dc
+	return nil
+}
+
+// Value - Returns the decimal as a string with its exact digits, as in ToString, implementing
+// driver.Valuer. Databases convert it to NUMERIC and DECIMAL columns without loss
+func (d Decimal) Value() (driver.Value, error) {
+	return d.ToString(), nil
+}
+
+// NullDecimal - A decimal that may be NULL, for nullable database columns, like sql.NullString.
+// Valid is false when the decimal is NULL
+type NullDecimal struct {
+	Decimal Decimal
+	Valid   bool
+}
+
+// Scan - Sets the decimal to a value read from a database like Decimal.Scan, implementing sql.Scanner.
+// NULL sets Valid to false
+func (n *NullDecimal) Scan(src any) error {
+	if src == nil {
+		n.Decimal, n.Valid = Decimal{}, false
+		return nil
+	}
+	if err := n.Decimal.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value - Returns the decimal as a string like Decimal.Value, or nil if it is NULL, implementing
+// driver.Valuer
+func (n NullDecimal) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Decimal.Value()
+}
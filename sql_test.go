@@ -0,0 +1,110 @@
+package decimal_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/petrossordinas/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ sql.Scanner   = (*decimal.Decimal)(nil)
+	_ driver.Valuer = decimal.Decimal{}
+	_ sql.Scanner   = (*decimal.NullDecimal)(nil)
+	_ driver.Valuer = decimal.NullDecimal{}
+)
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		src       any
+		precision uint
+		result    string
+	}{
+		{"1234.5600", 0, "1234.5600"},
+		{[]byte("-0.05"), 0, "-0.05"},
+		{"123456789012345678901234567890.12", 0, "123456789012345678901234567890.12"},
+		{int64(42), 0, "42"},
+		{int64(-9223372036854775808), 0, "-9223372036854775808"},
+		{0.1, 0, "0.1"},
+		{1e21, 0, "1000000000000000000000"},
+		{"2.675", 2, "2.675"},
+		{int64(42), 2, "42"},
+		{2.675, 2, "2.675"},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		d := decimal.NewDecimal(0, tc.precision)
+		err := d.Scan(tc.src)
+		assert.Nil(err, "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(tc.result, d.ToString(), "Test No: %d - Should be equal", testNo+1)
+	}
+}
+
+func TestScanRows(t *testing.T) {
+	rows := []struct {
+		src    any
+		result string
+	}{
+		{"10.50", "10.50"},
+		{"1.2345", "1.2345"},
+		{[]byte("7"), "7"},
+		{0.125, "0.125"},
+		{int64(-3), "-3"},
+		{"0.75", "0.75"},
+	}
+	assert := assert.New(t)
+	// A decimal reused for every row gets the digits of each row
+	var d decimal.Decimal
+	var n decimal.NullDecimal
+	for testNo, row := range rows {
+		assert.Nil(d.Scan(row.src), "Test No: %d - Was not expecting error", testNo+1)
+		assert.Equal(row.result, d.ToString(), "Test No: %d - Should be equal", testNo+1)
+		assert.Nil(n.Scan(row.src), "Test No: %d - Was not expecting error", testNo+1)
+		assert.True(n.Valid, "Test No: %d - Should be valid", testNo+1)
+		assert.Equal(row.result, n.Decimal.ToString(), "Test No: %d - Should be equal", testNo+1)
+	}
+	assert.Nil(n.Scan("10.0"))
+	assert.Nil(n.Scan(nil))
+	assert.Nil(n.Scan("0.75"))
+	assert.Equal("0.75", n.Decimal.ToString())
+}
+
+func TestScanErrors(t *testing.T) {
+	tests := []any{nil, "abc", []byte(""), true, int32(1)}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		var d decimal.Decimal
+		assert.NotNil(d.Scan(tc), "Test No: %d - Was expecting error", testNo+1)
+	}
+}
+
+func TestValue(t *testing.T) {
+	assert := assert.New(t)
+	d, _ := decimal.NewDecimalFromString("-123456789012345678901234567890.10")
+	value, err := d.Value()
+	assert.Nil(err)
+	assert.Equal("-123456789012345678901234567890.10", value)
+}
+
+func TestNullDecimal(t *testing.T) {
+	assert := assert.New(t)
+	var n decimal.NullDecimal
+	assert.Nil(n.Scan("10.50"))
+	assert.True(n.Valid)
+	assert.Equal("10.50", n.Decimal.ToString())
+	value, err := n.Value()
+	assert.Nil(err)
+	assert.Equal("10.50", value)
+
+	assert.Nil(n.Scan(nil))
+	assert.False(n.Valid)
+	assert.True(n.Decimal.IsZero())
+	value, err = n.Value()
+	assert.Nil(err)
+	assert.Nil(value)
+
+	assert.NotNil(n.Scan("abc"))
+	assert.False(n.Valid)
+}
@@ -0,0 +1,173 @@
+package decimal
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// UnmarshalJSON - Decodes JSON data into the value pointed to by v like json.Unmarshal, then applies
+// the decimal struct tags of v with ApplyTags
+func UnmarshalJSON(data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	return ApplyTags(v)
+}
+
+// ApplyTags - Rounds the decimals of the struct pointed to by v to the precision given by their
+// decimal struct tag, so that decoded values get the scale of their field. The tag has the options
+// scale, the precision, round, the rounding mode, which is halfup when not given, and exact, which
+// makes ApplyTags return an error matching ErrInexact instead of discarding non-zero digits. For
+// example:
+//
+//	type Payment struct {
+//		Amount decimal.Decimal  `json:"amount" decimal:"scale=2"`
+//		Rate   *decimal.Decimal `json:"rate" decimal:"scale=6,round=halfeven"`
+//		Fee    decimal.Decimal  `json:"fee" decimal:"scale=2,exact"`
+//	}
+//
+// The rounding modes are halfup, halfeven, halfdown, ceiling, floor, down, up and 05up. Tags may be
+// put on fields of type Decimal, StringDecimal, NullDecimal, pointers to them and slices or arrays of
+// them, and nested structs are visited as well.
+func ApplyTags(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("apply decimal tags: expected a non-nil pointer, got %T", v)
+	}
+	return applyTags(rv.Elem(), "")
+}
+
+// decimalType - The reflect type of Decimal
+var decimalType = reflect.TypeOf(Decimal{})
+
+// roundingModeNames - The names of the rounding modes in decimal struct tags
+var roundingModeNames = map[string]RoundingMode{
+	"halfup":   RoundHalfUp,
+	"halfeven": RoundHalfEven,
+	"halfdown": RoundHalfDown,
+	"ceiling":  RoundCeiling,
+	"floor":    RoundFloor,
+	"down":     RoundDown,
+	"up":       RoundUp,
+	"05up":     Round05Up,
+}
+
+// tagRule - The options of a decimal struct tag
+type tagRule struct {
+	scale uint
+	mode  RoundingMode
+	exact bool
+}
+
+// applyTags - Applies the decimal struct tags of the fields of v and of the structs it contains.
+// path is the name of v used in errors
+func applyTags(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			return applyTags(v.Elem(), path)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := applyTags(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if v.Type() == decimalType {
+			return nil
+		}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Name
+			if path != "" {
+				name = path + "." + name
+			}
+			tag, ok := field.Tag.Lookup("decimal")
+			if !ok {
+				if err := applyTags(v.Field(i), name); err != nil {
+					return err
+				}
+				continue
+			}
+			rule, err := parseTag(tag)
+			if err != nil {
+				return fmt.Errorf("apply decimal tags: field %s: %w", name, err)
+			}
+			if err := rule.apply(v.Field(i), name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseTag - Parses the options of a decimal struct tag
+func parseTag(tag string) (tagRule, error) {
+	var rule tagRule
+	hasScale := false
+	for _, option := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "scale":
+			scale, err := strconv.ParseUint(value, 10, 0)
+			if err != nil || scale > maxParseDigits {
+				return rule, fmt.Errorf("invalid scale %q", value)
+			}
+			rule.scale = uint(scale)
+			hasScale = true
+		case "round":
+			mode, ok := roundingModeNames[value]
+			if !ok {
+				return rule, fmt.Errorf("unknown rounding mode %q", value)
+			}
+			rule.mode = mode
+		case "exact":
+			rule.exact = true
+		default:
+			return rule, fmt.Errorf("unknown option %q", option)
+		}
+	}
+	if !hasScale {
+		return rule, fmt.Errorf("missing scale in %q", tag)
+	}
+	return rule, nil
+}
+
+// apply - Rounds the decimals of v, a field with the decimal struct tag of the rule
+func (r tagRule) apply(v reflect.Value, path string) error {
+	switch {
+	case v.Type() == decimalType:
+		d, exact := v.Interface().(Decimal).Rescale(r.scale, r.mode)
+		if r.exact && !exact {
+			return fmt.Errorf("apply decimal tags: field %s: %w", path, ErrInexact)
+		}
+		v.Set(reflect.ValueOf(d))
+		return nil
+	case v.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return r.apply(v.Elem(), path)
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := r.apply(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case v.Kind() == reflect.Struct:
+		// Types wrapping a decimal, like StringDecimal and NullDecimal, have their decimal rounded
+		if field, ok := v.Type().FieldByName("Decimal"); ok && field.Type == decimalType && len(field.Index) == 1 {
+			return r.apply(v.Field(field.Index[0]), path)
+		}
+	}
+	return fmt.Errorf("apply decimal tags: field %s: decimal tag on type %s", path, v.Type())
+}
@@ -0,0 +1,86 @@
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/petrossordinas/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+type payment struct {
+	Amount  decimal.Decimal       `json:"amount" decimal:"scale=2"`
+	Rate    *decimal.Decimal      `json:"rate" decimal:"scale=4,round=halfeven"`
+	Fee     decimal.StringDecimal `json:"fee" decimal:"scale=2,round=down"`
+	Splits  []decimal.Decimal     `json:"splits" decimal:"scale=1"`
+	Raw     decimal.Decimal       `json:"raw"`
+	Nested  *payment              `json:"nested"`
+	Entries []struct {
+		Price decimal.Decimal `json:"price" decimal:"scale=3,round=ceiling"`
+	} `json:"entries"`
+}
+
+func TestUnmarshalJSONWithTags(t *testing.T) {
+	data := `{
+		"amount": 10.5,
+		"rate": "1.23455",
+		"fee": "0.999",
+		"splits": [1, 2.25, -2.25],
+		"raw": 1.2500,
+		"nested": {"amount": 3},
+		"entries": [{"price": 0.0001}]
+	}`
+	assert := assert.New(t)
+	var p payment
+	assert.Nil(decimal.UnmarshalJSON([]byte(data), &p))
+	assert.Equal("10.50", p.Amount.ToString())
+	assert.Equal("1.2346", p.Rate.ToString())
+	assert.Equal("0.99", p.Fee.ToString())
+	assert.Equal("1.0", p.Splits[0].ToString())
+	assert.Equal("2.3", p.Splits[1].ToString())
+	assert.Equal("-2.3", p.Splits[2].ToString())
+	assert.Equal("1.2500", p.Raw.ToString())
+	assert.Equal("3.00", p.Nested.Amount.ToString())
+	assert.Nil(p.Nested.Rate)
+	assert.Equal("0.001", p.Entries[0].Price.ToString())
+}
+
+func TestApplyTagsExact(t *testing.T) {
+	type fee struct {
+		Amount decimal.Decimal `decimal:"scale=2,exact"`
+	}
+	assert := assert.New(t)
+	f := fee{Amount: *decimal.NewDecimal(12500, 3)}
+	assert.Nil(decimal.ApplyTags(&f))
+	assert.Equal("12.50", f.Amount.ToString())
+	f = fee{Amount: *decimal.NewDecimal(12505, 3)}
+	err := decimal.ApplyTags(&f)
+	assert.ErrorIs(err, decimal.ErrInexact)
+	assert.Equal("apply decimal tags: field Amount: inexact", err.Error())
+}
+
+func TestApplyTagsErrors(t *testing.T) {
+	tests := []any{
+		payment{},
+		nil,
+		(*payment)(nil),
+		&struct {
+			Amount decimal.Decimal `decimal:"round=halfeven"`
+		}{},
+		&struct {
+			Amount decimal.Decimal `decimal:"scale=two"`
+		}{},
+		&struct {
+			Amount decimal.Decimal `decimal:"scale=2,round=nearest"`
+		}{},
+		&struct {
+			Amount decimal.Decimal `decimal:"scale=2,precise"`
+		}{},
+		&struct {
+			Amount float64 `decimal:"scale=2"`
+		}{},
+	}
+	assert := assert.New(t)
+	for testNo, tc := range tests {
+		assert.NotNil(decimal.ApplyTags(tc), "Test No: %d - Was expecting error", testNo+1)
+	}
+}